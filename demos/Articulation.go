@@ -0,0 +1,525 @@
+package demos
+
+//////////////////////////////////////////////// Articulation
+// (goimo)
+// A reduced-coordinate articulation. A tree of rigid bodies is connected by articulation joints and simulated with
+// Featherstone's articulated-body algorithm, so the joints never drift apart regardless of the chain length or the
+// mass ratios. Create an articulation with its root rigid body, add links via `Articulation.AddLink`, and add the
+// articulation to a world through `World.AddArticulation`. The rigid bodies must be added to the world as well.
+//
+// The rigid bodies of the links still collide with others and are solved in islands. The velocity and position
+// changes made there are projected back onto the joint coordinates.
+
+type Articulation struct {
+	next *Articulation
+	prev *Articulation
+
+	world *World
+
+	links    []*ArticulationLink
+	numLinks int
+
+	// reference point of the spatial quantities
+	origin Vec3
+
+	// joint limits and motors solved in joint space
+	rows    []*articulationRow
+	numRows int
+
+	userData any
+}
+
+// A joint limit or motor of a single degree of freedom.
+type articulationRow struct {
+	link *ArticulationLink
+
+	// inverse effective mass in joint space
+	invMass float64
+	cfm     float64
+
+	// target joint velocity
+	rhs        float64
+	minImpulse float64
+	maxImpulse float64
+	impulse    float64
+}
+
+func NewArticulation(config *ArticulationConfig) *Articulation {
+	a := &Articulation{}
+	root := NewArticulationLink(a, nil, config.RootRigidBody)
+	a.links = append(a.links, root)
+	a.numLinks = 1
+	return a
+}
+
+// --- double linked list interface ---
+
+func (a *Articulation) GetNext() *Articulation {
+	return a.next
+}
+
+func (a *Articulation) SetNext(x *Articulation) {
+	a.next = x
+}
+
+func (a *Articulation) GetPrev() *Articulation {
+	return a.prev
+}
+
+func (a *Articulation) SetPrev(x *Articulation) {
+	a.prev = x
+}
+
+// --- internal ---
+
+// called when the link is added to a world
+func (self *Articulation) attachLink(link *ArticulationLink) {
+	rb := link.rigidBody
+	if rb.articulationLink != nil {
+		panic("A rigid body cannot belong to multiple articulations.")
+	}
+	rb.articulationLink = link
+	rb.autoSleep = false
+	rb.WakeUp()
+}
+
+// called when the link is removed from a world
+func (self *Articulation) detachLink(link *ArticulationLink) {
+	rb := link.rigidBody
+	rb.articulationLink = nil
+	rb.autoSleep = true
+	rb.WakeUp()
+}
+
+func (self *Articulation) isFixedBase() bool {
+	return self.links[0].rigidBody._type != RigidBodyType_DYNAMIC
+}
+
+// Computes the spatial inertias and motion subspaces in the current configuration.
+func (self *Articulation) updateKinematics() {
+	self.origin = self.links[0].rigidBody.transform.position
+
+	for i := range self.numLinks {
+		l := self.links[i]
+		rb := l.rigidBody
+		l.com = rb.transform.position.Sub(self.origin)
+		MathUtil.Mat3_transformInertia(&l.inertia, &rb.localInertia, &rb.transform.rotation)
+		if i > 0 {
+			l.updateSubspace(self.origin)
+		}
+	}
+}
+
+// Computes the articulated inertias from leaves to the root.
+func (self *Articulation) computeArticulatedInertias() {
+	for i := range self.numLinks {
+		l := self.links[i]
+		l.artInertia.setInertia(l.rigidBody.mass, &l.inertia, l.com)
+	}
+
+	for i := self.numLinks - 1; i > 0; i-- {
+		l := self.links[i]
+		l.artInertia.mulSubspace(&l.u, &l.subspace)
+
+		d := _subspaceInner(&l.subspace, &l.u)
+		// fill the unused degrees of freedom so that the matrix can be inverted
+		switch l.numDofs() {
+		case 0:
+			d.Identity()
+		case 1:
+			d.e11, d.e22 = 1, 1
+		}
+		MathUtil.Mat3_inv(&l.dInv, &d)
+
+		reduced := l.artInertia
+		reduced.subCongruence(&l.u, &l.dInv)
+		l.parent.artInertia.addEq(&reduced)
+	}
+}
+
+// Computes the changes of the velocities by the spatial impulses `impulse` and the joint impulses `tau` of the links,
+// by propagating the articulated impulses from leaves to the root, and then the velocity changes from the root to
+// leaves. The results are stored in `dVel` and `dJoint`.
+func (self *Articulation) propagateImpulses() {
+	for i := range self.numLinks {
+		l := self.links[i]
+		l.artImpulse = l.impulse
+	}
+
+	for i := self.numLinks - 1; i > 0; i-- {
+		l := self.links[i]
+		l.uTau = l.tau.Add(l.subspace.mulVecTransposed(l.artImpulse))
+		l.parent.artImpulse = l.parent.artImpulse.add(l.artImpulse).sub(l.u.mulVec(l.uTau.MulMat3(&l.dInv)))
+	}
+
+	root := self.links[0]
+	if self.isFixedBase() {
+		root.dVel = spatialVec{}
+	} else {
+		root.dVel = root.artInertia.solve(root.artImpulse)
+	}
+	root.dJoint.Zero()
+
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		du := l.uTau.Sub(l.u.mulVecTransposed(l.parent.dVel))
+		l.dJoint = du.MulMat3(&l.dInv)
+		l.dVel = l.parent.dVel.add(l.subspace.mulVec(l.dJoint))
+	}
+}
+
+// Adds the velocity changes computed by `propagateImpulses` to the joint velocities and the root velocity.
+func (self *Articulation) applyVelocityChanges() {
+	root := self.links[0]
+	if !self.isFixedBase() {
+		root.vel = root.vel.add(root.dVel)
+	}
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		l.jointVelocity = l.jointVelocity.Add(l.dJoint)
+	}
+}
+
+// Computes the spatial velocities of the links from the root velocity and the joint velocities.
+func (self *Articulation) updateVelocities() {
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		l.vel = l.parent.vel.add(l.subspace.mulVec(l.jointVelocity))
+	}
+}
+
+// Applies a joint impulse `impulse` to the joint of `link` and updates the joint velocities.
+func (self *Articulation) applyJointImpulse(link *ArticulationLink, impulse float64) {
+	for i := range self.numLinks {
+		l := self.links[i]
+		l.impulse = spatialVec{}
+		l.tau.Zero()
+	}
+	link.tau.x = impulse
+	self.propagateImpulses()
+	self.applyVelocityChanges()
+}
+
+func (self *Articulation) addRow(link *ArticulationLink) *articulationRow {
+	if self.numRows == len(self.rows) {
+		self.rows = append(self.rows, &articulationRow{})
+	}
+	row := self.rows[self.numRows]
+	self.numRows++
+	*row = articulationRow{link: link}
+	return row
+}
+
+func (self *Articulation) setLimitRows(link *ArticulationLink, lower, upper, invDt float64) {
+	if lower > upper {
+		return
+	}
+	// speculative bounds keeping the joint coordinate within the limits after the step
+	q := link.jointPosition
+	row := self.addRow(link)
+	row.rhs = (lower - q) * invDt
+	row.minImpulse = 0
+	row.maxImpulse = MathUtil.POSITIVE_INFINITY
+	row = self.addRow(link)
+	row.rhs = (upper - q) * invDt
+	row.minImpulse = MathUtil.NEGATIVE_INFINITY
+	row.maxImpulse = 0
+}
+
+func (self *Articulation) setMotorRow(link *ArticulationLink, motorSpeed, maxForce, target, stiffness, damping float64, timeStep *TimeStep) {
+	if maxForce <= 0 {
+		return
+	}
+	row := self.addRow(link)
+	row.rhs = motorSpeed
+	row.maxImpulse = maxForce * timeStep.Dt
+	row.minImpulse = -row.maxImpulse
+	if stiffness > 0 || damping > 0 {
+		h := timeStep.Dt
+		err := link.jointPosition - target
+		if link.jointType == ArticulationJointType_REVOLUTE {
+			err = _wrapAngle(err)
+		}
+		row.rhs = (damping*motorSpeed - stiffness*err) / (h*stiffness + damping)
+		row.cfm = 1 / (h * (h*stiffness + damping))
+	}
+}
+
+// Solves the joint limits and motors by sequential joint impulses.
+func (self *Articulation) solveRows(timeStep *TimeStep, numIterations int) {
+	self.numRows = 0
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		switch l.jointType {
+		case ArticulationJointType_REVOLUTE:
+			lm := l.rotationalLimitMotor
+			self.setMotorRow(l, lm.MotorSpeed, lm.MotorTorque, lm.ServoTarget, lm.ServoStiffness, lm.ServoDamping, timeStep)
+			self.setLimitRows(l, lm.LowerLimit, lm.UpperLimit, timeStep.InvDt)
+		case ArticulationJointType_PRISMATIC:
+			lm := l.translationalLimitMotor
+			self.setMotorRow(l, lm.MotorSpeed, lm.MotorForce, lm.ServoTarget, lm.ServoStiffness, lm.ServoDamping, timeStep)
+			self.setLimitRows(l, lm.LowerLimit, lm.UpperLimit, timeStep.InvDt)
+		}
+	}
+	if self.numRows == 0 {
+		return
+	}
+
+	// compute the joint-space inverse effective masses by test impulses
+	for i := range self.numRows {
+		row := self.rows[i]
+		for j := range self.numLinks {
+			l := self.links[j]
+			l.impulse = spatialVec{}
+			l.tau.Zero()
+		}
+		row.link.tau.x = 1
+		self.propagateImpulses()
+		row.invMass = row.link.dJoint.x
+	}
+
+	for range numIterations {
+		for i := range self.numRows {
+			row := self.rows[i]
+			qd := row.link.jointVelocity.x
+			old := row.impulse
+			imp := old + (row.rhs-qd-old*row.cfm)/(row.invMass+row.cfm)
+
+			// one-sided bounds are satisfied without impulses while the velocity is within them
+			imp = MathUtil.Clamp(imp, row.minImpulse, row.maxImpulse)
+			if imp != old {
+				row.impulse = imp
+				self.applyJointImpulse(row.link, imp-old)
+			}
+		}
+	}
+}
+
+// Computes the spatial impulses of the links that move the velocities of the links to the velocities of the rigid
+// bodies.
+func (self *Articulation) velocityImpulses(fixedBase bool) {
+	for i := range self.numLinks {
+		l := self.links[i]
+		rb := l.rigidBody
+		l.tau.Zero()
+		if i == 0 && fixedBase {
+			l.impulse = spatialVec{}
+			continue
+		}
+		lin := rb.vel.Sub(l.vel.pointVelocity(l.com))
+		lin.ScaleEq(rb.mass)
+		ang := rb.angVel.Sub(l.vel.w)
+		ang.MulMat3Eq(&l.inertia)
+		l.impulse = spatialVec{ang.Add(l.com.Cross(lin)), lin}
+	}
+}
+
+// Updates the joint velocities and solves the joint limits and motors, and writes the link velocities to the rigid
+// bodies before the island solve.
+//
+// The velocities of the rigid bodies still carry the motion in the previous configuration. Projecting them onto the
+// joint space of the current configuration, together with the external impulses, transfers the momentum between the
+// links like the velocity-dependent forces do, without the energy gain of integrating them explicitly.
+func (self *Articulation) preSolve(timeStep *TimeStep, gravity Vec3, numIterations int) {
+	dt := timeStep.Dt
+	fixedBase := self.isFixedBase()
+	self.updateKinematics()
+	self.computeArticulatedInertias()
+
+	root := self.links[0]
+	rb := root.rigidBody
+	root.vel = spatialVec{rb.angVel, rb.vel}
+	self.updateVelocities()
+
+	self.velocityImpulses(fixedBase)
+	for i := range self.numLinks {
+		l := self.links[i]
+		if i == 0 && fixedBase {
+			continue
+		}
+		lrb := l.rigidBody
+		force := gravity.Scale(lrb.gravityScale * lrb.mass)
+		force.AddEq(lrb.force)
+		torque := lrb.torque.Add(l.com.Cross(force))
+		l.impulse.w.AddScaledEq(torque, dt)
+		l.impulse.v.AddScaledEq(force, dt)
+	}
+	self.propagateImpulses()
+	self.applyVelocityChanges()
+
+	// damping
+	if !fixedBase {
+		root.vel.v.ScaleEq(fastInvExp(dt * rb.linearDamping))
+		root.vel.w.ScaleEq(fastInvExp(dt * rb.angularDamping))
+	}
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		damping := l.rigidBody.angularDamping
+		if l.jointType == ArticulationJointType_PRISMATIC {
+			damping = l.rigidBody.linearDamping
+		}
+		l.jointVelocity.ScaleEq(fastInvExp(dt * damping))
+	}
+
+	self.solveRows(timeStep, numIterations)
+	self.updateVelocities()
+
+	for i := range self.numLinks {
+		l := self.links[i]
+		lrb := l.rigidBody
+		if i > 0 || !fixedBase {
+			l.writeVelocity()
+		}
+		lrb.pTransform = lrb.transform
+		lrb.linearContactImpulse.Zero()
+		lrb.angularContactImpulse.Zero()
+		l.preVel = lrb.vel
+		l.preAngVel = lrb.angVel
+	}
+}
+
+// Projects the velocity and position changes made by the island solve onto the joint coordinates, integrates them,
+// and places the rigid bodies.
+func (self *Articulation) postSolve(timeStep *TimeStep) {
+	dt := timeStep.Dt
+	fixedBase := self.isFixedBase()
+
+	// velocity changes to impulses
+	for i := range self.numLinks {
+		l := self.links[i]
+		rb := l.rigidBody
+		l.tau.Zero()
+		if i == 0 && fixedBase {
+			l.impulse = spatialVec{}
+			continue
+		}
+		lin := rb.vel.Sub(l.preVel)
+		lin.ScaleEq(rb.mass)
+		ang := rb.angVel.Sub(l.preAngVel)
+		ang.MulMat3Eq(&l.inertia)
+		l.impulse = spatialVec{ang.Add(l.com.Cross(lin)), lin}
+	}
+	self.propagateImpulses()
+	self.applyVelocityChanges()
+	self.updateVelocities()
+	for i := range self.numLinks {
+		if i > 0 || !fixedBase {
+			self.links[i].writeVelocity()
+		}
+	}
+
+	// position corrections to joint displacements
+	for i := range self.numLinks {
+		l := self.links[i]
+		rb := l.rigidBody
+		l.tau.Zero()
+		if i == 0 && fixedBase {
+			l.impulse = spatialVec{}
+			continue
+		}
+		rb.integratePseudoVelocity()
+		lin := rb.transform.position.Sub(rb.pTransform.position)
+		lin.ScaleEq(rb.mass)
+		ang := _rotationVector(&rb.pTransform.rotation, &rb.transform.rotation)
+		ang.MulMat3Eq(&l.inertia)
+		l.impulse = spatialVec{ang.Add(l.com.Cross(lin)), lin}
+	}
+	self.propagateImpulses()
+
+	// integrate the root
+	root := self.links[0]
+	rb := root.rigidBody
+	if rb._type != RigidBodyType_STATIC {
+		rb.transform = rb.pTransform
+		rb.integrate(dt)
+		if !fixedBase {
+			rb.applyTranslation(root.dVel.v)
+			rb.applyRotation(root.dVel.w)
+		}
+	}
+
+	// integrate the joints and place the links
+	for i := 1; i < self.numLinks; i++ {
+		l := self.links[i]
+		d := l.jointVelocity.Scale(dt)
+		l.integrateJoint(d.Add(l.dJoint))
+		l.updateTransform()
+	}
+
+	for i := range self.numLinks {
+		self.links[i].rigidBody.syncShapes()
+	}
+}
+
+// Writes the velocities of the links computed from the joint velocities to the rigid bodies.
+func (self *Articulation) syncVelocities() {
+	root := self.links[0]
+	self.updateKinematics()
+	root.vel = spatialVec{root.rigidBody.angVel, root.rigidBody.vel}
+	self.updateVelocities()
+	for i := 1; i < self.numLinks; i++ {
+		self.links[i].writeVelocity()
+	}
+}
+
+// --- public ---
+
+// Adds a link to the articulation by the config `config`, and returns the link.
+func (self *Articulation) AddLink(config *ArticulationLinkConfig) *ArticulationLink {
+	if config.Parent == nil || config.Parent.articulation != self {
+		panic("The parent link doesn't belong to the articulation.")
+	}
+	if config.RigidBody._type != RigidBodyType_DYNAMIC {
+		panic("The rigid body of a link must be dynamic.")
+	}
+
+	l := NewArticulationLink(self, config.Parent, config.RigidBody)
+	l.index = self.numLinks
+	l.jointType = config.JointType
+	l.localAnchor1 = config.LocalAnchor1
+	l.localAnchor2 = config.LocalAnchor2
+	l.localAxis1 = config.LocalAxis1
+	l.rotationalLimitMotor = config.RotationalLimitMotor.Clone()
+	l.translationalLimitMotor = config.TranslationalLimitMotor.Clone()
+
+	// the current configuration is the zero of the joint coordinates
+	parentRotationT := _mat3Transposed(&config.Parent.rigidBody.transform.rotation)
+	MathUtil.Mat3_mul(&l.restRotation, &parentRotationT, &config.RigidBody.transform.rotation)
+
+	self.links = append(self.links, l)
+	self.numLinks++
+	if self.world != nil {
+		self.attachLink(l)
+	}
+	return l
+}
+
+// Returns the root link of the articulation.
+func (self *Articulation) GetRootLink() *ArticulationLink {
+	return self.links[0]
+}
+
+// Returns the link at `index`. The root link is at index `0`.
+func (self *Articulation) GetLink(index int) *ArticulationLink {
+	return self.links[index]
+}
+
+// Returns the number of the links including the root.
+func (self *Articulation) GetNumLinks() int {
+	return self.numLinks
+}
+
+// Returns the world the articulation belongs to.
+func (self *Articulation) GetWorld() *World {
+	return self.world
+}
+
+// Returns the user data of the articulation.
+func (self *Articulation) GetUserData() any {
+	return self.userData
+}
+
+// Sets the user data of the articulation to `userData`.
+func (self *Articulation) SetUserData(userData any) {
+	self.userData = userData
+}
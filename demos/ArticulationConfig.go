@@ -0,0 +1,21 @@
+package demos
+
+//////////////////////////////////////////////// ArticulationConfig
+// (goimo)
+// An articulation config is used for constructions of articulations.
+
+type ArticulationConfig struct {
+	// The rigid body at the root of the articulation tree. The articulation has a fixed base if the root is static or
+	// kinematic, and a floating base if the root is dynamic.
+	RootRigidBody *RigidBody
+}
+
+func NewArticulationConfig() *ArticulationConfig {
+	return &ArticulationConfig{}
+}
+
+// Sets the root rigid body and returns `this`.
+func (self *ArticulationConfig) Init(rootRigidBody *RigidBody) *ArticulationConfig {
+	self.RootRigidBody = rootRigidBody
+	return self
+}
@@ -0,0 +1,18 @@
+package demos
+
+///////////////////////////////// ArticulationJointType
+// (goimo)
+// The list of the types of the joints connecting an articulation link to its parent.
+
+type ArticulationJointType int
+
+const (
+	// The link is welded to its parent.
+	ArticulationJointType_FIXED ArticulationJointType = iota
+	// The link rotates about an axis fixed in its parent.
+	ArticulationJointType_REVOLUTE
+	// The link slides along an axis fixed in its parent.
+	ArticulationJointType_PRISMATIC
+	// The link rotates freely about an anchor point.
+	ArticulationJointType_SPHERICAL
+)
@@ -0,0 +1,208 @@
+package demos
+
+//////////////////////////////////////////////// ArticulationLink
+// (goimo)
+// A link of an articulation. A link owns a rigid body and the joint connecting it to its parent link, and its motion
+// is described by the joint coordinates relative to the parent.
+
+type ArticulationLink struct {
+	articulation *Articulation
+	parent       *ArticulationLink
+	index        int
+
+	rigidBody *RigidBody
+
+	jointType    ArticulationJointType
+	localAnchor1 Vec3
+	localAnchor2 Vec3
+	localAxis1   Vec3
+
+	// relative rotation at zero joint coordinates, in the parent's frame
+	restRotation Mat3
+
+	rotationalLimitMotor    *RotationalLimitMotor
+	translationalLimitMotor *TranslationalLimitMotor
+
+	// joint coordinates
+	jointPosition float64
+	jointRotation Mat3
+	jointVelocity Vec3
+
+	// per-step data of the articulated-body algorithm
+	com      Vec3
+	inertia  Mat3
+	subspace spatialSubspace
+	vel      spatialVec
+	dVel     spatialVec
+
+	artInertia spatialMat
+	artImpulse spatialVec
+	u          spatialSubspace
+	dInv       Mat3
+	tau        Vec3
+	uTau       Vec3
+	dJoint     Vec3
+	impulse    spatialVec
+
+	preVel    Vec3
+	preAngVel Vec3
+}
+
+func NewArticulationLink(articulation *Articulation, parent *ArticulationLink, rigidBody *RigidBody) *ArticulationLink {
+	l := &ArticulationLink{
+		articulation: articulation,
+		parent:       parent,
+		rigidBody:    rigidBody,
+		jointType:    ArticulationJointType_FIXED,
+	}
+	l.restRotation.Identity()
+	l.jointRotation.Identity()
+	return l
+}
+
+// --- internal ---
+
+// Returns the number of degrees of freedom of the joint.
+func (self *ArticulationLink) numDofs() int {
+	switch self.jointType {
+	case ArticulationJointType_REVOLUTE, ArticulationJointType_PRISMATIC:
+		return 1
+	case ArticulationJointType_SPHERICAL:
+		return 3
+	}
+	return 0
+}
+
+// Computes the world anchor relative to `origin` and the world axis of the joint from the parent's transform.
+func (self *ArticulationLink) jointFrame(origin Vec3) (anchor, axis Vec3) {
+	ptf := &self.parent.rigidBody.transform
+	anchor = self.localAnchor1.MulMat3(&ptf.rotation)
+	anchor.AddEq(ptf.position).SubEq(origin)
+	axis = self.localAxis1.MulMat3(&ptf.rotation)
+	return
+}
+
+// Updates the motion subspace of the joint in the current configuration.
+func (self *ArticulationLink) updateSubspace(origin Vec3) {
+	anchor, axis := self.jointFrame(origin)
+	self.subspace.w.Zero()
+	self.subspace.v.Zero()
+
+	switch self.jointType {
+	case ArticulationJointType_REVOLUTE:
+		v := anchor.Cross(axis)
+		self.subspace.w.e00, self.subspace.w.e10, self.subspace.w.e20 = axis.x, axis.y, axis.z
+		self.subspace.v.e00, self.subspace.v.e10, self.subspace.v.e20 = v.x, v.y, v.z
+	case ArticulationJointType_PRISMATIC:
+		self.subspace.v.e00, self.subspace.v.e10, self.subspace.v.e20 = axis.x, axis.y, axis.z
+	case ArticulationJointType_SPHERICAL:
+		// the joint velocity is the relative angular velocity in the parent's frame
+		self.subspace.w = self.parent.rigidBody.transform.rotation
+		ax := _mat3Skew(anchor)
+		MathUtil.Mat3_mul(&self.subspace.v, &ax, &self.subspace.w)
+	}
+}
+
+// Places the rigid body according to the parent's transform and the joint coordinates.
+func (self *ArticulationLink) updateTransform() {
+	ptf := &self.parent.rigidBody.transform
+	tf := &self.rigidBody.transform
+
+	var rel Mat3
+	switch self.jointType {
+	case ArticulationJointType_REVOLUTE:
+		rot := _rotationFromVector(self.localAxis1.Scale(self.jointPosition))
+		MathUtil.Mat3_mul(&rel, &rot, &self.restRotation)
+	case ArticulationJointType_SPHERICAL:
+		MathUtil.Mat3_mul(&rel, &self.jointRotation, &self.restRotation)
+	default:
+		rel = self.restRotation
+	}
+	MathUtil.Mat3_mul(&tf.rotation, &ptf.rotation, &rel)
+
+	anchor := self.localAnchor1.MulMat3(&ptf.rotation)
+	anchor.AddEq(ptf.position)
+	if self.jointType == ArticulationJointType_PRISMATIC {
+		axis := self.localAxis1.MulMat3(&ptf.rotation)
+		anchor.AddScaledEq(axis, self.jointPosition)
+	}
+	tf.position = anchor.Sub(self.localAnchor2.MulMat3(&tf.rotation))
+
+	self.rigidBody.updateInvInertia()
+}
+
+// Advances the joint coordinates by the joint displacement `d`.
+func (self *ArticulationLink) integrateJoint(d Vec3) {
+	switch self.jointType {
+	case ArticulationJointType_REVOLUTE, ArticulationJointType_PRISMATIC:
+		self.jointPosition += d.x
+	case ArticulationJointType_SPHERICAL:
+		rot := _rotationFromVector(d)
+		MathUtil.Mat3_mul(&self.jointRotation, &rot, &self.jointRotation)
+	}
+}
+
+// Sets the velocity of the rigid body from the spatial velocity of the link.
+func (self *ArticulationLink) writeVelocity() {
+	self.rigidBody.angVel = self.vel.w
+	self.rigidBody.vel = self.vel.pointVelocity(self.com)
+}
+
+// --- public ---
+
+// Returns the articulation the link belongs to.
+func (self *ArticulationLink) GetArticulation() *Articulation {
+	return self.articulation
+}
+
+// Returns the parent link, or `nil` if the link is the root.
+func (self *ArticulationLink) GetParent() *ArticulationLink {
+	return self.parent
+}
+
+// Returns the index of the link in the articulation. Parents always have smaller indices than their children.
+func (self *ArticulationLink) GetIndex() int {
+	return self.index
+}
+
+// Returns the rigid body of the link.
+func (self *ArticulationLink) GetRigidBody() *RigidBody {
+	return self.rigidBody
+}
+
+// Returns the type of the joint connecting the link to the parent.
+func (self *ArticulationLink) GetJointType() ArticulationJointType {
+	return self.jointType
+}
+
+// Returns the rotational limits and motor settings of a revolute joint.
+func (self *ArticulationLink) GetRotationalLimitMotor() *RotationalLimitMotor {
+	return self.rotationalLimitMotor
+}
+
+// Returns the translational limits and motor settings of a prismatic joint.
+func (self *ArticulationLink) GetTranslationalLimitMotor() *TranslationalLimitMotor {
+	return self.translationalLimitMotor
+}
+
+// Returns the joint angle in radians of a revolute joint, or the joint translation in meters of a prismatic joint.
+func (self *ArticulationLink) GetJointPosition() float64 {
+	return self.jointPosition
+}
+
+// Returns the relative rotation of a spherical joint in the parent's frame.
+func (self *ArticulationLink) GetJointRotation() Mat3 {
+	return self.jointRotation
+}
+
+// Returns the joint velocity. Revolute and prismatic joints use only the x component, and spherical joints use all
+// the components as the relative angular velocity in the parent's frame.
+func (self *ArticulationLink) GetJointVelocity() Vec3 {
+	return self.jointVelocity
+}
+
+// Sets the joint velocity to `jointVelocity`. See `GetJointVelocity` for details.
+func (self *ArticulationLink) SetJointVelocity(jointVelocity Vec3) {
+	self.jointVelocity = jointVelocity
+	self.articulation.syncVelocities()
+}
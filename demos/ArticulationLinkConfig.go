@@ -0,0 +1,54 @@
+package demos
+
+//////////////////////////////////////////////// ArticulationLinkConfig
+// (goimo)
+// An articulation link config is used for adding links to articulations.
+
+type ArticulationLinkConfig struct {
+	// The parent link. The link is connected to the parent by the joint.
+	Parent *ArticulationLink
+
+	// The rigid body of the link. The rigid body must be dynamic.
+	RigidBody *RigidBody
+
+	// The type of the joint connecting the link to the parent.
+	// See `ArticulationJointType` for details.
+	JointType ArticulationJointType
+
+	// The local position of the parent's anchor point.
+	LocalAnchor1 Vec3
+
+	// The local position of the link's anchor point.
+	LocalAnchor2 Vec3
+
+	// The parent's local joint axis, used by revolute and prismatic joints.
+	LocalAxis1 Vec3
+
+	// The rotational limits and motor settings, used by revolute joints.
+	RotationalLimitMotor *RotationalLimitMotor
+
+	// The translational limits and motor settings, used by prismatic joints.
+	TranslationalLimitMotor *TranslationalLimitMotor
+}
+
+func NewArticulationLinkConfig() *ArticulationLinkConfig {
+	return &ArticulationLinkConfig{
+		JointType:               ArticulationJointType_REVOLUTE,
+		LocalAxis1:              Vec3{1, 0, 0},
+		RotationalLimitMotor:    NewRotationalLimitMotor(),
+		TranslationalLimitMotor: NewTranslationalLimitMotor(),
+	}
+}
+
+// Sets the parent link, the rigid body, the joint type, local anchors from the world anchor `worldAnchor`, the local
+// axis from the world axis `worldAxis`, and returns `this`.
+func (self *ArticulationLinkConfig) Init(parent *ArticulationLink, rigidBody *RigidBody, jointType ArticulationJointType, worldAnchor, worldAxis Vec3) *ArticulationLinkConfig {
+	self.Parent = parent
+	self.RigidBody = rigidBody
+	self.JointType = jointType
+	parent.rigidBody.GetLocalPointTo(worldAnchor, &self.LocalAnchor1)
+	rigidBody.GetLocalPointTo(worldAnchor, &self.LocalAnchor2)
+	parent.rigidBody.GetLocalVectorTo(worldAxis, &self.LocalAxis1)
+	self.LocalAxis1.Normalize()
+	return self
+}
@@ -0,0 +1,45 @@
+package demos
+
+import (
+	"testing"
+)
+
+func TestArticulationChain(t *testing.T) {
+	w := groundTestWorld()
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	rc.Position = Vec3{0, 20, 0}
+	ceiling := NewRigidBody(rc)
+	w.AddRigidBody(ceiling)
+
+	// a horizontal chain of 20 links falls and swings from the ceiling, with a weight 1000 times heavier at the end
+	a := NewArticulation(NewArticulationConfig().Init(ceiling))
+	parent := a.GetRootLink()
+	for i := range 20 {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{0.25 + 0.5*float64(i), 20, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.2, 0.05, 0.05})
+		if i == 19 {
+			sc.Density = 1000
+		}
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		parent = a.AddLink(NewArticulationLinkConfig().Init(parent, rb, ArticulationJointType_SPHERICAL, Vec3{0.5 * float64(i), 20, 0}, Vec3{1, 0, 0}))
+	}
+	w.AddArticulation(a)
+
+	stepWorld(w, 120)
+	if y := a.GetLink(20).GetRigidBody().GetPosition().y; y > 15 {
+		t.Fatalf("the chain didn't swing down: %v", y)
+	}
+	for i := 1; i <= 20; i++ {
+		link := a.GetLink(i)
+		anchor1 := link.GetParent().GetRigidBody().GetWorldPoint(link.localAnchor1)
+		anchor2 := link.GetRigidBody().GetWorldPoint(link.localAnchor2)
+		if d := anchor2.Sub(anchor1); d.Length() > 1e-3 {
+			t.Fatalf("link %d drifted apart by %v", i, d.Length())
+		}
+	}
+}
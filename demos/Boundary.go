@@ -35,4 +35,104 @@ func NewBoundary(maxRows int) *Boundary {
 	return b
 }
 
-// TODO
+func (self *Boundary) init(buildInfo *BoundaryBuildInfo) {
+	// copy bounded part
+	self.numBounded = buildInfo.numBounded
+	for i := range self.numBounded {
+		self.iBounded[i] = buildInfo.iBounded[i]
+		self.signs[i] = buildInfo.signs[i]
+	}
+
+	// copy unbounded part and compute matrix id
+	self.numUnbounded = buildInfo.numUnbounded
+	self.matrixId = 0
+	for i := range self.numUnbounded {
+		idx := buildInfo.iUnbounded[i]
+		self.iUnbounded[i] = idx
+		self.matrixId |= 1 << idx
+	}
+}
+
+// Computes delta impulses `dImpulses` assuming the boundary, returns whether the boundary satisfies the complementarity
+// conditions. If `noCheck` is true, the impulses are computed without checking the conditions and `true` is returned.
+func (self *Boundary) computeImpulses(info *JointSolverInfo, mass *MassMatrix, relVels, impulses, dImpulses []float64, impulseFactor float64, noCheck bool) bool {
+	// b = rhs - relVel - cfm * impulse
+	for i := range self.numUnbounded {
+		idx := self.iUnbounded[i]
+		row := info.rows[idx]
+		self.b[idx] = row.rhs*impulseFactor - relVels[idx] - row.cfm*impulses[idx]
+	}
+
+	// bounded impulses are fixed, remove their effect from b
+	invMassWithoutCfm := mass.invMassWithoutCfm
+	for i := range self.numBounded {
+		idx := self.iBounded[i]
+		sign := self.signs[i]
+		row := info.rows[idx]
+		oldImpulse := impulses[idx]
+		impulse := 0.0
+		if sign < 0 {
+			impulse = row.minImpulse
+		} else if sign > 0 {
+			impulse = row.maxImpulse
+		}
+		dImpulse := impulse - oldImpulse
+		dImpulses[idx] = dImpulse
+
+		if dImpulse != 0 {
+			for j := range self.numUnbounded {
+				idx2 := self.iUnbounded[j]
+				// delta relative velocity
+				self.b[idx2] -= invMassWithoutCfm[idx][idx2] * dImpulse
+			}
+		}
+	}
+
+	massMatrix := mass.getSubmatrix(self.iUnbounded, self.numUnbounded)
+	ok := true
+
+	// compute unbounded impulses, all of them when not checking the bounds
+	for i := range self.numUnbounded {
+		idx := self.iUnbounded[i]
+		row := info.rows[idx]
+		oldImpulse := impulses[idx]
+		impulse := oldImpulse
+		for j := range self.numUnbounded {
+			impulse += self.b[self.iUnbounded[j]] * massMatrix[i][j]
+		}
+		if !noCheck && (impulse < row.minImpulse-Settings.DirectMlcpSolverEps || impulse > row.maxImpulse+Settings.DirectMlcpSolverEps) {
+			ok = false
+			break
+		}
+		dImpulses[idx] = impulse - oldImpulse
+	}
+
+	if noCheck {
+		return true
+	}
+	if !ok {
+		return false
+	}
+
+	// check bounded impulses
+	for i := range self.numBounded {
+		idx := self.iBounded[i]
+		row := info.rows[idx]
+		sign := self.signs[i]
+		newImpulse := impulses[idx] + dImpulses[idx]
+
+		// relative velocity after the impulses are applied
+		relVel := relVels[idx]
+		for j := range info.numRows {
+			relVel += invMassWithoutCfm[idx][j] * dImpulses[j]
+		}
+
+		err := row.rhs*impulseFactor - relVel - row.cfm*newImpulse
+		if sign < 0 && err > Settings.DirectMlcpSolverEps || sign > 0 && err < -Settings.DirectMlcpSolverEps {
+			ok = false
+			break
+		}
+	}
+
+	return ok
+}
@@ -26,4 +26,26 @@ func NewBoundaryBuildInfo(size int) *BoundaryBuildInfo {
 	}
 }
 
-// TODO
+func (self *BoundaryBuildInfo) clear() {
+	self.numBounded = 0
+	self.numUnbounded = 0
+}
+
+func (self *BoundaryBuildInfo) pushBounded(idx, sign int) {
+	self.iBounded[self.numBounded] = idx
+	self.signs[self.numBounded] = sign
+	self.numBounded++
+}
+
+func (self *BoundaryBuildInfo) pushUnbounded(idx int) {
+	self.iUnbounded[self.numUnbounded] = idx
+	self.numUnbounded++
+}
+
+func (self *BoundaryBuildInfo) popBounded() {
+	self.numBounded--
+}
+
+func (self *BoundaryBuildInfo) popUnbounded() {
+	self.numUnbounded--
+}
@@ -21,4 +21,56 @@ func NewBoundaryBuilder(maxRows int) *BoundaryBuilder {
 	}
 }
 
-// TODO
+func (self *BoundaryBuilder) buildBoundariesRecursive(info *JointSolverInfo, i int) {
+	if i == info.numRows {
+		// the number of boundaries can exceed the initial capacity
+		if self.numBoundaries == len(self.boundaries) {
+			self.boundaries = append(self.boundaries, nil)
+		}
+		if self.boundaries[self.numBoundaries] == nil {
+			self.boundaries[self.numBoundaries] = NewBoundary(self.maxRows)
+		}
+		self.boundaries[self.numBoundaries].init(self.bbInfo)
+		self.numBoundaries++
+		return
+	}
+
+	row := info.rows[i]
+	lowerLimitEnabled := row.minImpulse > MathUtil.NEGATIVE_INFINITY
+	upperLimitEnabled := row.maxImpulse < MathUtil.POSITIVE_INFINITY
+	disabled := row.minImpulse == 0 && row.maxImpulse == 0
+
+	if disabled {
+		// try inactive case
+		self.bbInfo.pushBounded(i, 0)
+		self.buildBoundariesRecursive(info, i+1)
+		self.bbInfo.popBounded()
+		return
+	}
+
+	// try unbounded case
+	self.bbInfo.pushUnbounded(i)
+	self.buildBoundariesRecursive(info, i+1)
+	self.bbInfo.popUnbounded()
+
+	// try lower bounded case
+	if lowerLimitEnabled {
+		self.bbInfo.pushBounded(i, -1)
+		self.buildBoundariesRecursive(info, i+1)
+		self.bbInfo.popBounded()
+	}
+
+	// try upper bounded case
+	if upperLimitEnabled {
+		self.bbInfo.pushBounded(i, 1)
+		self.buildBoundariesRecursive(info, i+1)
+		self.bbInfo.popBounded()
+	}
+}
+
+func (self *BoundaryBuilder) buildBoundaries(info *JointSolverInfo) {
+	self.numBoundaries = 0
+	self.bbInfo.clear()
+	self.bbInfo.size = info.numRows
+	self.buildBoundariesRecursive(info, 0)
+}
@@ -22,4 +22,42 @@ func NewBoundarySelector(n int) *BoundarySelector {
 	return bc
 }
 
-// TODO
+func (self *BoundarySelector) getIndex(i int) int {
+	return self.indices[i]
+}
+
+// Moves the boundary `index` to the front, so that it is tried first next time.
+func (self *BoundarySelector) selectBoundary(index int) {
+	i := 0
+	for self.indices[i] != index {
+		i++
+	}
+	for i > 0 {
+		self.indices[i], self.indices[i-1] = self.indices[i-1], self.indices[i]
+		i--
+	}
+}
+
+// Makes sure the first `size` indices are less than `size`, keeping their order.
+func (self *BoundarySelector) setSize(size int) {
+	// the number of boundaries can exceed the initial capacity
+	for self.n < size {
+		self.indices = append(self.indices, self.n)
+		self.tmpIndices = append(self.tmpIndices, 0)
+		self.n++
+	}
+
+	numSmaller := 0
+	numGreater := 0
+	for i := range self.n {
+		idx := self.indices[i]
+		if idx < size {
+			self.tmpIndices[numSmaller] = idx
+			numSmaller++
+		} else {
+			self.tmpIndices[size+numGreater] = idx
+			numGreater++
+		}
+	}
+	self.indices, self.tmpIndices = self.tmpIndices, self.indices
+}
@@ -115,19 +115,25 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 	proj2 := bbd.project(x1, sx2, sy2, sz2)
 	projC12 := x1.Dot(c12)
 	// _satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, x1, 0, 1.0)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, x1, 0, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, x1, 0, 1.0) {
+		return
+	}
 
 	// try axis = y1
 	proj1 = h1
 	proj2 = bbd.project(y1, sx2, sy2, sz2)
 	projC12 = y1.Dot(c12)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, y1, 1, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, y1, 1, 1.0) {
+		return
+	}
 
 	// try axis = z1
 	proj1 = d1
 	proj2 = bbd.project(z1, sx2, sy2, sz2)
 	projC12 = z1.Dot(c12)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, z1, 2, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, z1, 2, 1.0) {
+		return
+	}
 
 	// apply bias to avoid jitting
 	if mDepth > Settings.LinearSlop {
@@ -140,19 +146,25 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 	proj1 = bbd.project(x2, sx1, sy1, sz1)
 	proj2 = w2
 	projC12 = x2.Dot(c12)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, x2, 3, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, x2, 3, 1.0) {
+		return
+	}
 
 	// try axis = y2
 	proj1 = bbd.project(y2, sx1, sy1, sz1)
 	proj2 = h2
 	projC12 = y2.Dot(c12)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, y2, 4, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, y2, 4, 1.0) {
+		return
+	}
 
 	// try axis = z2
 	proj1 = bbd.project(z2, sx1, sy1, sz1)
 	proj2 = d2
 	projC12 = z2.Dot(c12)
-	_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, z2, 5, 1.0)
+	if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, z2, 5, 1.0) {
+		return
+	}
 
 	// --------------------- 9 edges ---------------------
 
@@ -170,7 +182,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sy1, sz1)
 		proj2 = bbd.project2(edgeAxis, sy2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 6, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 6, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(x1, y2)
@@ -180,7 +194,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sy1, sz1)
 		proj2 = bbd.project2(edgeAxis, sx2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 7, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 7, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(x1, z2)
@@ -190,7 +206,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sy1, sz1)
 		proj2 = bbd.project2(edgeAxis, sx2, sy2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 8, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 8, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(y1, x2)
@@ -200,7 +218,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sz1)
 		proj2 = bbd.project2(edgeAxis, sy2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 9, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 9, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(y1, y2)
@@ -210,7 +230,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sz1)
 		proj2 = bbd.project2(edgeAxis, sx2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 10, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 10, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(y1, z2)
@@ -220,7 +242,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sz1)
 		proj2 = bbd.project2(edgeAxis, sx2, sy2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 11, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 11, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(z1, x2)
@@ -230,7 +254,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sy1)
 		proj2 = bbd.project2(edgeAxis, sy2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 12, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 12, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(z1, y2)
@@ -240,7 +266,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sy1)
 		proj2 = bbd.project2(edgeAxis, sx2, sz2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 13, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 13, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// try cross(z1, z2)
@@ -250,7 +278,9 @@ func (bbd *BoxBoxDetector) detectImpl(result *DetectorResult, geom1, geom2 IGeom
 		proj1 = bbd.project2(edgeAxis, sx1, sy1)
 		proj2 = bbd.project2(edgeAxis, sx2, sy2)
 		projC12 = edgeAxis.Dot(c12)
-		_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 14, EDGE_BIAS_MULT)
+		if !_satCheck(&mDepth, &mId, &mSign, &mAxis, proj1, proj2, projC12, edgeAxis, 14, EDGE_BIAS_MULT) {
+			return
+		}
 	}
 
 	// --------------------- edge-edge collision check ---------------------
@@ -505,7 +535,8 @@ func (bbd *BoxBoxDetector) project2(axis, sx, sy Vec3) float64 {
 
 // --- Macros ---
 
-func _satCheck(minDepth *float64, minDepthId, minDepthSign *int, minDepthAxis *Vec3, proj1, proj2, projC12 float64, axis Vec3, id int, biasMult float64) {
+// Returns false if `axis` is a separating axis, the caller must return then.
+func _satCheck(minDepth *float64, minDepthId, minDepthSign *int, minDepthAxis *Vec3, proj1, proj2, projC12 float64, axis Vec3, id int, biasMult float64) bool {
 	sum := proj1 + proj2
 	neg := projC12 < 0
 	abs := projC12
@@ -525,7 +556,9 @@ func _satCheck(minDepth *float64, minDepthId, minDepthSign *int, minDepthAxis *V
 				*minDepthSign = 1
 			}
 		}
+		return true
 	}
+	return false
 }
 
 func _supportingVertexRect(out *Vec3, halfExtX, halfExtY, axis Vec3) {
@@ -740,7 +773,7 @@ func (fc *FaceClipper) reduce() {
 				min1 = dot1
 				min1V = v
 			}
-			if dot1 > max2 {
+			if dot2 > max2 {
 				max2 = dot2
 				max2V = v
 			}
@@ -0,0 +1,35 @@
+package demos
+
+import "testing"
+
+func detectBoxes(tf1, tf2 *Transform) *DetectorResult {
+	box := NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	result := NewDetectorResult()
+	NewBoxBoxDetector().Detect(result, box, box, tf1, tf2, NewCachedDetectorData())
+	return result
+}
+
+func TestBoxBoxDetectorSeparated(t *testing.T) {
+	// rotated boxes about 0.5 apart along an edge-edge axis, and overlapping along all the face axes
+	tf1 := NewTransform()
+	tf2 := NewTransform()
+	MathUtil.Mat3_fromEulerXyz(&tf1.rotation, &Vec3{3.9, 3.31, 4.53})
+	MathUtil.Mat3_fromEulerXyz(&tf2.rotation, &Vec3{4.15, 4.27, 3.38})
+	tf2.position = Vec3{1.28, 1.36, -0.46}
+	if result := detectBoxes(tf1, tf2); result.numPoints != 0 {
+		t.Fatalf("separated boxes gave %d contact points", result.numPoints)
+	}
+
+	// apart along a face axis
+	tf1.Identity()
+	tf2.Identity()
+	tf2.position = Vec3{1.2, 0, 0}
+	if result := detectBoxes(tf1, tf2); result.numPoints != 0 {
+		t.Fatalf("separated boxes gave %d contact points", result.numPoints)
+	}
+
+	tf2.position = Vec3{0.95, 0, 0}
+	if result := detectBoxes(tf1, tf2); result.numPoints == 0 {
+		t.Fatal("overlapping boxes gave no contact points")
+	}
+}
@@ -24,6 +24,7 @@ func NewBoxGeometry(halfExtents Vec3) *BoxGeometry {
 		halfAxisY:      Vec3{0, halfExtents.y, 0},
 		halfAxisZ:      Vec3{0, 0, halfExtents.z},
 	}
+	b.impl = b
 	b.UpdateMass()
 
 	minHalfExtents := math.Min(math.Min(halfExtents.x, halfExtents.y), halfExtents.z)
@@ -57,6 +57,7 @@ type BroadPhase struct {
 
 	convexSweep *ConvexSweepGeometry
 	aabb        *AabbGeometry
+	gjkEpa      *GjkEpa
 
 	identity   Transform
 	zero       Vec3
@@ -69,6 +70,7 @@ func NewBroadPhase(_type_ BroadPhaseType) *BroadPhase {
 
 		convexSweep: NewConvexSweepGeometry(),
 		aabb:        NewAabbGeometry(),
+		gjkEpa:      NewGjkEpa(),
 	}
 	b.identity.Identity()
 	return b
@@ -177,8 +179,9 @@ func (self *BroadPhase) _aabbConvexSweepTest(aabbMin, aabbMax Vec3, convex IConv
 	self.aabb.max = aabbMax
 	self.convexSweep.Set(convex, begin, translation)
 
-	if GjkEpaInstance.ComputeDistance(self.convexSweep, self.aabb, begin, &self.identity, nil) == GjkEpaResultState_SUCCEEDED {
-		return GjkEpaInstance.Distance <= 0
+	if self.gjkEpa.ComputeDistance(self.convexSweep, self.aabb, begin, &self.identity, nil) == GjkEpaResultState_SUCCEEDED {
+		// the distance is measured between the cores, so the margin of the swept geometry counts as overlap
+		return self.gjkEpa.Distance <= convex.GetGjkMargin()
 	}
 	return false
 }
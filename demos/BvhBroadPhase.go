@@ -240,6 +240,28 @@ func (self *BvhBroadPhase) AabbTest(aabb *Aabb, callback IBroadPhaseProxyCallbac
 	self._aabbTestRecursive(self.Tree.root, aabb, callback)
 }
 
+// Saves the tree and the proxies moved since the pairs were last collected.
+func (self *BvhBroadPhase) save() *bvhSnapshot {
+	snapshot := &bvhSnapshot{}
+	self.Tree.save(snapshot)
+	snapshot.movedProxies = append(snapshot.movedProxies, self.movedProxies[:self.numMovedProxies]...)
+	return snapshot
+}
+
+// Restores the tree and the moved proxies saved in `snapshot`. The fat AABBs of the proxies must be restored first.
+func (self *BvhBroadPhase) restore(snapshot *bvhSnapshot) {
+	self.Tree.restore(snapshot)
+
+	for i := range self.numMovedProxies {
+		self.movedProxies[i].Moved = false
+		self.movedProxies[i] = nil
+	}
+	self.numMovedProxies = 0
+	for _, p := range snapshot.movedProxies {
+		self._addToMovedProxy(p)
+	}
+}
+
 // Returns the balance of the bounding volume tree.
 func (self *BvhBroadPhase) getTreeBalance() int {
 	return self.Tree.getBalance()
@@ -21,12 +21,16 @@ type BvhTree struct {
 	leafListLast *BvhNode
 
 	tmp []*BvhNode
+
+	// seeded, so that the tree is the same every run
+	rng *rand.Rand
 }
 
 func NewBvhTree() *BvhTree {
 	return &BvhTree{
 		strategy: NewBvhStrategy(),
 		tmp:      make([]*BvhNode, 1024),
+		rng:      rand.New(rand.NewSource(0)),
 	}
 }
 
@@ -92,6 +96,29 @@ func (self *BvhTree) insertProxy(proxy *BvhProxy) {
 	self._insertLeaf(leaf)
 }
 
+// Saves the structure of the tree into `snapshot`.
+func (self *BvhTree) save(snapshot *bvhSnapshot) {
+	if self.root != nil {
+		self._saveRecursive(self.root, snapshot)
+	}
+	for leaf := self.leafList; leaf != nil; leaf = leaf.nextLeaf {
+		snapshot.leaves = append(snapshot.leaves, leaf.proxy.(*BvhProxy))
+	}
+}
+
+// Rebuilds the tree saved in `snapshot`, the leaves are connected to the same proxies.
+func (self *BvhTree) restore(snapshot *bvhSnapshot) {
+	self.clear()
+	if len(snapshot.nodes) > 0 {
+		i := 0
+		self.root = self._restoreRecursive(snapshot.nodes, &i)
+	}
+	for _, p := range snapshot.leaves {
+		BvhNode_push(&self.leafList, &self.leafListLast, p.Leaf)
+		self.numLeaves++
+	}
+}
+
 // Deletes the proxy. This also deletes the leaf connected to the proxy from the tree and `leafList`.
 func (self *BvhTree) deleteProxy(proxy *BvhProxy) {
 	leaf := proxy.Leaf
@@ -127,7 +154,7 @@ func (self *BvhTree) optimize(count int) {
 			h2 := float64(leaf.children[1].height)
 			// TODO(oimo): better strategy
 			ix := 0
-			if rand.Float64() > (h1 / (h1 + h2)) {
+			if self.rng.Float64() > (h1 / (h1 + h2)) {
 				ix = 1
 			}
 			leaf = leaf.children[ix]
@@ -185,6 +212,40 @@ func (self *BvhTree) _deleteRecursive(root *BvhNode) {
 	self._pool(root)
 }
 
+func (self *BvhTree) _saveRecursive(node *BvhNode, snapshot *bvhSnapshot) {
+	ns := bvhNodeSnapshot{
+		height:  node.height,
+		aabbMin: node.aabbMin,
+		aabbMax: node.aabbMax,
+	}
+	if node.height == 0 {
+		ns.proxy = node.proxy.(*BvhProxy)
+	}
+	snapshot.nodes = append(snapshot.nodes, ns)
+	if node.height > 0 {
+		self._saveRecursive(node.children[0], snapshot)
+		self._saveRecursive(node.children[1], snapshot)
+	}
+}
+
+func (self *BvhTree) _restoreRecursive(nodes []bvhNodeSnapshot, i *int) *BvhNode {
+	ns := &nodes[*i]
+	*i++
+
+	node := self._pick()
+	node.height = ns.height
+	node.aabbMin = ns.aabbMin
+	node.aabbMax = ns.aabbMax
+	if ns.proxy != nil {
+		node.proxy = ns.proxy
+		ns.proxy.Leaf = node
+		return node
+	}
+	node.setChild(0, self._restoreRecursive(nodes, i))
+	node.setChild(1, self._restoreRecursive(nodes, i))
+	return node
+}
+
 func (self *BvhTree) _decomposeRecursive(root *BvhNode) {
 	if root.height == 0 {
 		root.childIndex = 0
@@ -0,0 +1,143 @@
+package demos
+
+//////////////////////////////////////////////// CapsuleGeometry
+// (oimo/collision/geometry/CapsuleGeometry.go)
+// A capsule collision geometry aligned with the y-axis.
+
+type CapsuleGeometry struct {
+	*ConvexGeometry
+
+	radius     float64
+	halfHeight float64
+}
+
+// Creates a capsule collision geometry of radius `radius` and half-height of the cylinder part `halfHeight`.
+func NewCapsuleGeometry(radius, halfHeight float64) *CapsuleGeometry {
+	c := &CapsuleGeometry{
+		ConvexGeometry: NewConvexGeometry(GeometryType_CAPSULE),
+		radius:         radius,
+		halfHeight:     halfHeight,
+	}
+	c.impl = c
+	c.gjkMargin = radius
+	c.UpdateMass()
+	return c
+}
+
+// Returns the radius of the capsule.
+func (c *CapsuleGeometry) GetRadius() float64 {
+	return c.radius
+}
+
+// Returns the half-height of the cylinder part of the capsule.
+func (c *CapsuleGeometry) GetHalfHeight() float64 {
+	return c.halfHeight
+}
+
+func (c *CapsuleGeometry) UpdateMass() { // override
+	r2 := c.radius * c.radius
+	hh2 := c.halfHeight * c.halfHeight
+
+	cylinderVolume := MathUtil.TWO_PI * r2 * c.halfHeight
+	sphereVolume := MathUtil.PI * r2 * c.radius * 4 / 3
+	c.volume = cylinderVolume + sphereVolume
+
+	invVolume := 0.0
+	if c.volume != 0 {
+		invVolume = 1 / c.volume
+	}
+
+	inertiaY := invVolume * (cylinderVolume*r2*0.5 + sphereVolume*r2*0.4)
+	inertiaXZ := invVolume * (cylinderVolume*(r2*0.25+hh2/3) + sphereVolume*(r2*0.4+c.halfHeight*c.radius*0.75+hh2))
+
+	MathUtil.Mat3_diagonal(&c.inertiaCoeff, inertiaXZ, inertiaY, inertiaXZ)
+}
+
+func (c *CapsuleGeometry) ComputeAabb(aabb *Aabb, tf *Transform) { // override
+	radVec := Vec3{c.radius, c.radius, c.radius}
+	axis := tf.rotation.GetCol(1)
+	MathUtil.Vec3_abs(&axis, &axis)
+	radVec.AddScaledEq(axis, c.halfHeight)
+	aabb.Min = tf.position.Sub(radVec)
+	aabb.Max = tf.position.Add(radVec)
+}
+
+func (c *CapsuleGeometry) ComputeLocalSupportingVertex(dir Vec3, out *Vec3) { // override
+	if dir.y > 0 {
+		out.Set(0, c.halfHeight, 0)
+	} else {
+		out.Set(0, -c.halfHeight, 0)
+	}
+}
+
+func (c *CapsuleGeometry) RayCastLocal(begin, end Vec3, hit *RayCastHit) bool { // override
+	halfH := c.halfHeight
+	dx := end.x - begin.x
+	dz := end.z - begin.z
+
+	// XZ
+	tminxz := 0.0
+	a := dx*dx + dz*dz
+	b := begin.x*dx + begin.z*dz
+	cc := begin.x*begin.x + begin.z*begin.z - c.radius*c.radius
+	D := b*b - a*cc
+	if D < 0 {
+		return false
+	}
+	if a > 0 {
+		sqrtD := MathUtil.Sqrt(D)
+		tminxz = (-b - sqrtD) / a
+		tmaxxz := (-b + sqrtD) / a
+		if tminxz >= 1 || tmaxxz <= 0 {
+			return false
+		}
+	} else if cc >= 0 {
+		return false
+	}
+
+	crossY := begin.y + (end.y-begin.y)*tminxz
+
+	if crossY > -halfH && crossY < halfH {
+		if tminxz > 0 {
+			// hit: side
+			hit.Normal.Set(begin.x+dx*tminxz, 0, begin.z+dz*tminxz)
+			hit.Normal.Normalize()
+			hit.Position.Set(begin.x+dx*tminxz, crossY, begin.z+dz*tminxz)
+			hit.Fraction = tminxz
+			return true
+		}
+		return false
+	}
+
+	// sphere test
+	spherePos := Vec3{0, halfH, 0}
+	if crossY < 0 {
+		spherePos.y = -halfH
+	}
+	sphereToBegin := begin.Sub(spherePos)
+	d := end.Sub(begin)
+
+	a = d.Dot(d)
+	b = sphereToBegin.Dot(d)
+	cc = sphereToBegin.Dot(sphereToBegin) - c.radius*c.radius
+
+	D = b*b - a*cc
+	if D < 0 {
+		return false
+	}
+
+	t := (-b - MathUtil.Sqrt(D)) / a
+	if t < 0 || t > 1 {
+		return false
+	}
+
+	hitPos := sphereToBegin.AddScaled(d, t)
+	hit.Normal = hitPos.Normalized()
+	hit.Position = hitPos.Add(spherePos)
+	hit.Fraction = t
+	return true
+}
+
+func (c *CapsuleGeometry) GetType() GeometryType {
+	return GeometryType_CAPSULE
+}
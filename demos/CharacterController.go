@@ -0,0 +1,435 @@
+package demos
+
+//////////////////////////////////////////////// CharacterController
+// (goimo)
+// A kinematic character controller moves a capsule through a world by convex casts, without being a rigid body of
+// the world. Each move slides along the shapes it hits, climbs steps, refuses to walk up steep slopes, snaps down to
+// the ground it walked on, and pushes the dynamic rigid bodies in its way.
+
+type CharacterController struct {
+	world   *World
+	capsule *CapsuleGeometry
+
+	// the center of the capsule, rotated to stand along `up`
+	transform Transform
+	up        Vec3
+
+	skinWidth     float64
+	maxSlopeAngle float64
+	minSlopeCos   float64
+	stepHeight    float64
+	snapDistance  float64
+	pushForce     float64
+	maxIterations int
+
+	collisionGroup int
+	collisionMask  int
+
+	grounded     bool
+	groundNormal Vec3
+	groundShape  *Shape
+
+	castCallback *characterCastCallback
+	rayCallback  *characterCastCallback
+	aabbCallback *characterAabbCallback
+	gjkEpa       *GjkEpa
+
+	// the pushes of the current move, applied once it is done
+	pushes []characterPush
+}
+
+type characterPush struct {
+	rigidBody *RigidBody
+	force     Vec3
+	position  Vec3
+}
+
+// keeps the closest hit of the shapes the character collides with
+type characterCastCallback struct { // implements IRayCastCallback
+	*RayCastClosest
+	controller *CharacterController
+}
+
+func (self *characterCastCallback) Process(shape *Shape, hit *RayCastHit) { // override
+	if self.controller.collidesWith(shape) {
+		self.RayCastClosest.Process(shape, hit)
+	}
+}
+
+// collects the shapes the character collides with
+type characterAabbCallback struct { // implements IAabbTestCallback
+	controller *CharacterController
+	shapes     []*Shape
+}
+
+func (self *characterAabbCallback) Process(shape *Shape) { // override
+	if self.controller.collidesWith(shape) {
+		self.shapes = append(self.shapes, shape)
+	}
+}
+
+// Creates a new character controller in the world `world` by configuration `config`.
+func NewCharacterController(world *World, config *CharacterControllerConfig) *CharacterController {
+	c := &CharacterController{
+		world:          world,
+		capsule:        NewCapsuleGeometry(config.Radius, config.HalfHeight),
+		up:             config.Up.Normalized(),
+		skinWidth:      config.SkinWidth,
+		stepHeight:     config.StepHeight,
+		snapDistance:   config.SnapDistance,
+		pushForce:      config.PushForce,
+		maxIterations:  config.MaxIterations,
+		collisionGroup: config.CollisionGroup,
+		collisionMask:  config.CollisionMask,
+		gjkEpa:         NewGjkEpa(),
+	}
+	c.castCallback = &characterCastCallback{RayCastClosest: NewRayCastClosest(), controller: c}
+	c.rayCallback = &characterCastCallback{RayCastClosest: NewRayCastClosest(), controller: c}
+	c.aabbCallback = &characterAabbCallback{controller: c}
+	c.SetMaxSlopeAngle(config.MaxSlopeAngle)
+
+	// the capsule is aligned with the y-axis
+	var q Quat
+	yAxis := Vec3{0, 1, 0}
+	MathUtil.Quat_arc(&q, &yAxis, &c.up)
+	MathUtil.Mat3_fromQuat(&c.transform.rotation, &q)
+	c.transform.position = config.Position
+
+	return c
+}
+
+// --- internal ---
+
+func (self *CharacterController) collidesWith(shape *Shape) bool {
+	return !shape.trigger && self.collisionGroup&shape.collisionMask != 0 && shape.collisionGroup&self.collisionMask != 0
+}
+
+func (self *CharacterController) isWalkable(normal Vec3) bool {
+	return normal.Dot(self.up) >= self.minSlopeCos
+}
+
+// sweeps the capsule by `translation` and returns whether it hits a shape, the hit is kept in `castCallback`
+func (self *CharacterController) cast(translation Vec3) bool {
+	self.castCallback.Clear()
+	self.world.ConvexCast(self.capsule, &self.transform, translation, self.castCallback)
+	return self.castCallback.Hit
+}
+
+// returns the normal of the surface the last cast hit
+func (self *CharacterController) hitNormal() Vec3 {
+	// the cast reports the direction from the capsule into the surface
+	normal := self.castCallback.Normal.Negate()
+	return normal.Normalized()
+}
+
+// returns the normal of the ground the last cast hit. The capsule resting on an edge gets a steep normal from it, so
+// the normal of the face just beyond the edge is taken instead if that face is walkable.
+func (self *CharacterController) hitGroundNormal() Vec3 {
+	normal := self.hitNormal()
+	if self.isWalkable(normal) {
+		return normal
+	}
+	beyond := self.up.Scale(normal.Dot(self.up))
+	beyond.SubEq(normal)
+	if beyond.LengthSq() < 1e-12 {
+		return normal
+	}
+	beyond.Normalize()
+
+	// a ray starting inside the shape doesn't hit it, so walls and steep slopes keep their normal
+	begin := self.castCallback.Position.AddScaled(beyond, self.skinWidth)
+	begin.AddScaledEq(self.up, self.skinWidth*2)
+	end := begin.AddScaled(self.up, -self.skinWidth*4)
+	self.rayCallback.Clear()
+	self.world.RayCast(begin, end, self.rayCallback)
+	if self.rayCallback.Hit && self.isWalkable(self.rayCallback.Normal) {
+		return self.rayCallback.Normal
+	}
+	return normal
+}
+
+// returns the distance the capsule can travel along `dir` toward the last hit, keeping the skin width from the
+// surface with normal `normal`
+func (self *CharacterController) travelDistance(dir Vec3, distance float64, normal Vec3) float64 {
+	travel := self.castCallback.Fraction * distance
+	if cos := -dir.Dot(normal); cos > 1e-3 {
+		travel -= self.skinWidth / cos
+	}
+	return max(travel, 0)
+}
+
+// pushes the capsule out of the shapes it overlaps, which moving rigid bodies and teleports can cause
+func (self *CharacterController) recover() {
+	var aabb Aabb
+	self.capsule.ComputeAabb(&aabb, &self.transform)
+	skin := Vec3{self.skinWidth, self.skinWidth, self.skinWidth}
+	aabb.Min.SubEq(skin)
+	aabb.Max.AddEq(skin)
+
+	self.aabbCallback.shapes = self.aabbCallback.shapes[:0]
+	self.world.AabbTest(&aabb, self.aabbCallback)
+	for _, shape := range self.aabbCallback.shapes {
+		convex, ok := shape.geom.(IConvexGeometry)
+		if !ok {
+			continue
+		}
+		if self.gjkEpa.ComputeDistance(self.capsule, convex, &self.transform, &shape.transform, nil) != GjkEpaResultState_SUCCEEDED {
+			continue
+		}
+		// the distance is between the cores, which is zero if they overlap too deep to tell the way out
+		coreDistance := self.gjkEpa.Distance
+		if coreDistance <= 0 {
+			continue
+		}
+		separation := coreDistance - self.capsule.gjkMargin - convex.GetGjkMargin()
+		if separation < self.skinWidth*0.5 {
+			normal := self.gjkEpa.ClosestPoint1.Sub(self.gjkEpa.ClosestPoint2)
+			self.transform.position.AddScaledEq(normal, (self.skinWidth-separation)/coreDistance)
+		}
+	}
+}
+
+// moves the capsule by `translation` until it hits a shape, and returns the distance moved
+func (self *CharacterController) moveStraight(translation Vec3) float64 {
+	distance := translation.Length()
+	if distance == 0 {
+		return 0
+	}
+	dir := translation.Scale(1 / distance)
+	if self.cast(translation) {
+		distance = self.travelDistance(dir, distance, self.hitNormal())
+	}
+	self.transform.position.AddScaledEq(dir, distance)
+	return distance
+}
+
+// moves the capsule by `translation` sliding along the shapes it hits, and returns whether a surface too steep to
+// walk on blocked it. Vertical moves stop on walkable surfaces instead of sliding down, horizontal moves slide along
+// steep surfaces without climbing them.
+func (self *CharacterController) moveAndSlide(translation Vec3, vertical bool) bool {
+	blocked := false
+	remaining := translation
+	var prevNormal Vec3
+	for i := range self.maxIterations {
+		distance := remaining.Length()
+		if distance < 1e-9 {
+			break
+		}
+		if !self.cast(remaining) {
+			self.transform.position.AddEq(remaining)
+			break
+		}
+
+		dir := remaining.Scale(1 / distance)
+		normal := self.hitNormal()
+		travel := self.travelDistance(dir, distance, normal)
+		self.transform.position.AddScaledEq(dir, travel)
+
+		shape := self.castCallback.Shape
+		if shape.rigidBody._type == RigidBodyType_DYNAMIC && self.pushForce > 0 {
+			self.pushes = append(self.pushes, characterPush{shape.rigidBody, dir.Scale(self.pushForce), self.castCallback.Position})
+		}
+
+		if vertical && dir.Dot(self.up) < 0 && self.isWalkable(self.hitGroundNormal()) {
+			break // landed
+		}
+		if !self.isWalkable(normal) {
+			blocked = true
+			if !vertical {
+				// treat the steep surface as a wall
+				normal.SubEq(self.up.Scale(normal.Dot(self.up)))
+				if normal.LengthSq() < 1e-12 {
+					break
+				}
+				normal.Normalize()
+			}
+		}
+
+		// slide along the surface, and along the crease of the last two surfaces if it would go back into the last
+		remaining = dir.Scale(distance - travel)
+		remaining.SubEq(normal.Scale(remaining.Dot(normal)))
+		if i > 0 && remaining.Dot(prevNormal) < 0 {
+			crease := prevNormal.Cross(normal)
+			if crease.LengthSq() < 1e-12 {
+				break
+			}
+			crease.Normalize()
+			remaining = crease.Scale(remaining.Dot(crease))
+		}
+		prevNormal = normal
+	}
+	return blocked
+}
+
+// moves the capsule by the horizontal translation `translation`, climbing a step that blocks it if `canStep` is true
+func (self *CharacterController) moveHorizontal(translation Vec3, canStep bool) {
+	start := self.transform.position
+	numPushes := len(self.pushes)
+	if !self.moveAndSlide(translation, false) || !canStep || self.stepHeight <= 0 {
+		return
+	}
+
+	slid := self.transform.position
+	slidPushes := append([]characterPush(nil), self.pushes[numPushes:]...)
+
+	// try again from the step height, and put the capsule down on the step
+	self.transform.position = start
+	self.pushes = self.pushes[:numPushes]
+	raised := self.moveStraight(self.up.Scale(self.stepHeight))
+	self.moveAndSlide(translation, false)
+	down := raised + self.skinWidth
+	if self.cast(self.up.Scale(-down)) {
+		if self.isWalkable(self.hitGroundNormal()) {
+			self.transform.position.AddScaledEq(self.up, -self.travelDistance(self.up.Negate(), down, self.hitNormal()))
+
+			// keep the step only if it got further
+			dir := translation.Normalized()
+			stepped := self.transform.position.Sub(start)
+			slidDelta := slid.Sub(start)
+			if stepped.Dot(dir) > slidDelta.Dot(dir)+1e-6 {
+				return
+			}
+		}
+	}
+
+	self.transform.position = slid
+	self.pushes = append(self.pushes[:numPushes], slidPushes...)
+}
+
+// looks for walkable ground within `distance` below the capsule, and moves the capsule down onto it
+func (self *CharacterController) findGround(distance float64) {
+	self.grounded = false
+	self.groundNormal.Zero()
+	self.groundShape = nil
+
+	if !self.cast(self.up.Scale(-distance)) {
+		return
+	}
+	normal := self.hitGroundNormal()
+	if !self.isWalkable(normal) {
+		return
+	}
+	self.transform.position.AddScaledEq(self.up, -self.travelDistance(self.up.Negate(), distance, self.hitNormal()))
+	self.grounded = true
+	self.groundNormal = normal
+	self.groundShape = self.castCallback.Shape
+}
+
+// --- public ---
+
+// Moves the character by the desired displacement `displacement`, and updates the ground state. The horizontal part
+// of the displacement is done first, climbing steps if the character is on the ground, then the vertical part. The
+// character is pulled down onto the ground within the snap distance if it was on the ground and isn't moving up.
+// Call this before `World.step`, as the dynamic rigid bodies the character walks into are pushed by forces.
+func (self *CharacterController) Move(displacement Vec3) {
+	wasGrounded := self.grounded
+	self.pushes = self.pushes[:0]
+
+	self.recover()
+
+	verticalDistance := displacement.Dot(self.up)
+	vertical := self.up.Scale(verticalDistance)
+	horizontal := displacement.Sub(vertical)
+
+	self.moveHorizontal(horizontal, wasGrounded)
+	self.moveAndSlide(vertical, true)
+
+	probe := self.skinWidth * 2
+	if wasGrounded && verticalDistance <= 0 {
+		probe += self.snapDistance
+	}
+	self.findGround(probe)
+
+	// push each rigid body once
+	for i, push := range self.pushes {
+		first := true
+		for _, prev := range self.pushes[:i] {
+			if prev.rigidBody == push.rigidBody {
+				first = false
+				break
+			}
+		}
+		if first {
+			push.rigidBody.ApplyForce(push.force, push.position)
+		}
+	}
+}
+
+// Returns the world position of the center of the capsule.
+func (self *CharacterController) GetPosition() Vec3 {
+	return self.transform.position
+}
+
+// Sets the world position of the center of the capsule to `position`, without moving through the shapes between.
+func (self *CharacterController) SetPosition(position Vec3) {
+	self.transform.position = position
+	self.grounded = false
+	self.groundNormal.Zero()
+	self.groundShape = nil
+}
+
+// Returns the transform of the capsule.
+func (self *CharacterController) GetTransform() Transform {
+	return self.transform
+}
+
+// Returns the capsule geometry of the character.
+func (self *CharacterController) GetCapsule() *CapsuleGeometry {
+	return self.capsule
+}
+
+// Returns whether the character stood on walkable ground after the last move.
+func (self *CharacterController) IsGrounded() bool {
+	return self.grounded
+}
+
+// Returns the normal of the ground the character stood on after the last move, or zero if it wasn't on the ground.
+func (self *CharacterController) GetGroundNormal() Vec3 {
+	return self.groundNormal
+}
+
+// Returns the shape the character stood on after the last move, or `nil` if it wasn't on the ground.
+func (self *CharacterController) GetGroundShape() *Shape {
+	return self.groundShape
+}
+
+// Returns the steepest slope in radians the character can walk up and stand on.
+func (self *CharacterController) GetMaxSlopeAngle() float64 {
+	return self.maxSlopeAngle
+}
+
+// Sets the steepest slope in radians the character can walk up and stand on to `maxSlopeAngle`.
+func (self *CharacterController) SetMaxSlopeAngle(maxSlopeAngle float64) {
+	self.maxSlopeAngle = maxSlopeAngle
+	self.minSlopeCos = MathUtil.Cos(maxSlopeAngle)
+}
+
+// Returns the highest step the character climbs.
+func (self *CharacterController) GetStepHeight() float64 {
+	return self.stepHeight
+}
+
+// Sets the highest step the character climbs to `stepHeight`.
+func (self *CharacterController) SetStepHeight(stepHeight float64) {
+	self.stepHeight = stepHeight
+}
+
+// Returns the furthest the character is pulled down to stay on the ground.
+func (self *CharacterController) GetSnapDistance() float64 {
+	return self.snapDistance
+}
+
+// Sets the furthest the character is pulled down to stay on the ground to `snapDistance`.
+func (self *CharacterController) SetSnapDistance(snapDistance float64) {
+	self.snapDistance = snapDistance
+}
+
+// Returns the force the character pushes dynamic rigid bodies with.
+func (self *CharacterController) GetPushForce() float64 {
+	return self.pushForce
+}
+
+// Sets the force the character pushes dynamic rigid bodies with to `pushForce`.
+func (self *CharacterController) SetPushForce(pushForce float64) {
+	self.pushForce = pushForce
+}
@@ -0,0 +1,60 @@
+package demos
+
+//////////////////////////////////////////////// CharacterControllerConfig
+// (goimo)
+// A character controller config is used for constructions of character controllers.
+
+type CharacterControllerConfig struct {
+	// The world position of the center of the capsule.
+	Position Vec3
+
+	// The up direction of the character. The capsule stands along it, and slopes and steps are measured against it.
+	Up Vec3
+
+	// The radius of the capsule.
+	Radius float64
+
+	// The half-height of the cylinder part of the capsule.
+	HalfHeight float64
+
+	// The gap kept between the capsule and the shapes it touches, so that the next move starts separated from them.
+	SkinWidth float64
+
+	// The steepest slope in radians the character can walk up and stand on.
+	MaxSlopeAngle float64
+
+	// The highest step the character climbs while walking on the ground.
+	StepHeight float64
+
+	// The furthest the character is pulled down to stay on the ground it walked on, when walking down slopes and
+	// steps. Set `0` to disable snapping.
+	SnapDistance float64
+
+	// The force the character pushes the dynamic rigid bodies it walks into with. Set `0` to disable pushing.
+	PushForce float64
+
+	// The maximum number of collide-and-slide iterations per move.
+	MaxIterations int
+
+	// The collision group bits of the character, see `ShapeConfig.CollisionGroup`.
+	CollisionGroup int
+
+	// The collision mask bits of the character, see `ShapeConfig.CollisionMask`.
+	CollisionMask int
+}
+
+func NewCharacterControllerConfig() *CharacterControllerConfig {
+	return &CharacterControllerConfig{
+		Up:             Vec3{0, 1, 0},
+		Radius:         0.3,
+		HalfHeight:     0.6,
+		SkinWidth:      0.02,
+		MaxSlopeAngle:  MathUtil.PI / 4,
+		StepHeight:     0.3,
+		SnapDistance:   0.2,
+		PushForce:      100,
+		MaxIterations:  4,
+		CollisionGroup: Settings.DefaultCollisionGroup,
+		CollisionMask:  Settings.DefaultCollisionMask,
+	}
+}
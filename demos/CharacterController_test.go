@@ -0,0 +1,102 @@
+package demos
+
+import (
+	"testing"
+)
+
+// adds a static box of half-extents `halfExtents` at `position` rotated by `angle` about the z-axis
+func addStaticBox(w *World, position, halfExtents Vec3, angle float64) {
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	rc.Position = position
+	MathUtil.Mat3_fromEulerXyz(&rc.Rotation, &Vec3{0, 0, angle})
+	b := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(halfExtents)
+	b.AddShape(NewShape(sc))
+	w.AddRigidBody(b)
+}
+
+// walks a character along the x-axis under gravity for `numSteps` steps
+func walkCharacter(c *CharacterController, speed float64, numSteps int) {
+	fall := 0.0
+	for range numSteps {
+		if c.IsGrounded() {
+			fall = 0
+		}
+		fall += 9.8 / 60 / 60
+		c.Move(Vec3{speed / 60, -fall, 0})
+	}
+}
+
+func TestCharacterControllerGround(t *testing.T) {
+	w := groundTestWorld()
+	config := NewCharacterControllerConfig()
+	config.Position = Vec3{0, 3, 0}
+	c := NewCharacterController(w, config)
+
+	// fall onto the ground, whose top is at 0.5
+	walkCharacter(c, 0, 120)
+	bottom := c.GetPosition().y - config.HalfHeight - config.Radius
+	if !c.IsGrounded() || bottom < 0.5 || bottom > 0.5+2*config.SkinWidth {
+		t.Fatalf("grounded: %v, bottom at %v", c.IsGrounded(), bottom)
+	}
+	if n := c.GetGroundNormal(); n.y < 0.99 {
+		t.Fatalf("ground normal %v", n)
+	}
+
+	// a step lower than the step height is climbed, a higher one blocks
+	addStaticBox(w, Vec3{2, 0.6, 0}, Vec3{0.5, 0.1, 2}, 0)
+	addStaticBox(w, Vec3{5, 1, 0}, Vec3{0.5, 0.5, 2}, 0)
+	walkCharacter(c, 2, 60)
+	if p := c.GetPosition(); p.x < 1.8 || p.x > 2.2 || !c.IsGrounded() || p.y-config.HalfHeight-config.Radius < 0.7 {
+		t.Fatalf("didn't climb the step: %v", p)
+	}
+	walkCharacter(c, 2, 120)
+	if p := c.GetPosition(); p.x > 4.5-config.Radius || p.x < 4 {
+		t.Fatalf("walked through the wall: %v", p)
+	}
+}
+
+func TestCharacterControllerSlopes(t *testing.T) {
+	for _, angle := range []float64{MathUtil.PI / 8, MathUtil.PI / 3} {
+		w := groundTestWorld()
+		// a ramp rising along the x-axis from x = 1
+		addStaticBox(w, Vec3{1 + 4*MathUtil.Cos(angle), 0.5 + 4*MathUtil.Sin(angle) - 0.5, 0}, Vec3{4, 0.5, 2}, angle)
+		config := NewCharacterControllerConfig()
+		config.Position = Vec3{-1, 1.5, 0}
+		c := NewCharacterController(w, config)
+		walkCharacter(c, 2, 150)
+
+		climbed := c.GetPosition().y > 2
+		if climbed != (angle < config.MaxSlopeAngle) {
+			t.Fatalf("slope %v: climbed to %v", angle, c.GetPosition())
+		}
+	}
+}
+
+func TestCharacterControllerPush(t *testing.T) {
+	w := groundTestWorld()
+	rc := NewRigidBodyConfig()
+	rc.Position = Vec3{1.5, 1, 0}
+	box := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	box.AddShape(NewShape(sc))
+	w.AddRigidBody(box)
+
+	config := NewCharacterControllerConfig()
+	config.Position = Vec3{0, 1.5, 0}
+	config.PushForce = 200
+	c := NewCharacterController(w, config)
+	for range 60 {
+		walkCharacter(c, 2, 1)
+		w.Step(1.0 / 60)
+	}
+	if box.GetPosition().x < 2 {
+		t.Fatalf("the box wasn't pushed: %v", box.GetPosition())
+	}
+	if c.GetPosition().x > box.GetPosition().x-0.5-config.Radius+0.01 {
+		t.Fatalf("walked into the box: %v, box at %v", c.GetPosition(), box.GetPosition())
+	}
+}
@@ -31,6 +31,15 @@ type Contact struct {
 	updater           *ManifoldUpdater
 	contactConstraint *ContactConstraint
 	touching          bool
+
+	// whether the shapes were touching before the last narrow-phase update, to send the events
+	ptouching bool
+
+	// whether the contact passes through a one-way shape, decided when the shapes start touching
+	passingOneWay bool
+
+	// whether either shape is a trigger, trigger contacts never fill the manifold
+	trigger bool
 }
 
 func NewContact() *Contact {
@@ -101,10 +110,10 @@ func (c *Contact) _sendBeginContact() {
 		cc2 = nil // avoid calling twice
 	}
 	if cc1 != nil {
-		cc1.beginContact(c)
+		cc1.BeginContact(c)
 	}
 	if cc2 != nil {
-		cc2.beginContact(c)
+		cc2.BeginContact(c)
 	}
 }
 
@@ -115,10 +124,10 @@ func (c *Contact) _sendEndContact() {
 		cc2 = nil // avoid calling twice
 	}
 	if cc1 != nil {
-		cc1.endContact(c)
+		cc1.EndContact(c)
 	}
 	if cc2 != nil {
-		cc2.endContact(c)
+		cc2.EndContact(c)
 	}
 }
 
@@ -129,10 +138,10 @@ func (c *Contact) _sendPreSolve() {
 		cc2 = nil // avoid calling twice
 	}
 	if cc1 != nil {
-		cc1.preSolve(c)
+		cc1.PreSolve(c)
 	}
 	if cc2 != nil {
-		cc2.preSolve(c)
+		cc2.PreSolve(c)
 	}
 }
 
@@ -143,10 +152,57 @@ func (c *Contact) _sendPostSolve() {
 		cc2 = nil // avoid calling twice
 	}
 	if cc1 != nil {
-		cc1.postSolve(c)
+		cc1.PostSolve(c)
 	}
 	if cc2 != nil {
-		cc2.postSolve(c)
+		cc2.PostSolve(c)
+	}
+}
+
+// Returns whether the shape `other` touches the one-way shape `s` from its back side. `sign` is the sign of the
+// manifold normal pointing from `s` to `other`.
+func (c *Contact) _isPassingOneWay(s, other *Shape, sign float64) bool {
+	if s.oneWayNormal.IsZero() {
+		return false
+	}
+	n := s.oneWayNormal.MulMat3(&s.transform.rotation)
+	n.Normalize()
+
+	// the contact pushes the other shape against the one-way normal
+	if c.manifold.normal.Dot(n)*sign <= 0 {
+		return true
+	}
+
+	// the other shape is moving out of the back side
+	relVel := other.rigidBody.vel.Sub(s.rigidBody.vel)
+	return relVel.Dot(n) > Settings.OneWayContactVelocityThreshold
+}
+
+// Updates the overlapping state of the trigger contact and sends trigger events.
+func (c *Contact) _updateTrigger(touching bool) {
+	c.ptouching = c.touching
+	c.touching = touching
+	c._sendTriggerEvents()
+}
+
+func (c *Contact) _sendTriggerEvents() {
+	touching, ptouching := c.touching, c.ptouching
+
+	trigger, other := c.s1, c.s2
+	if !trigger.trigger {
+		trigger, other = other, trigger
+	}
+	tc := trigger.triggerCallback
+	if tc == nil {
+		return
+	}
+	switch {
+	case touching && !ptouching:
+		tc.TriggerEnter(trigger, other)
+	case touching:
+		tc.TriggerStay(trigger, other)
+	case ptouching:
+		tc.TriggerExit(trigger, other)
 	}
 }
 
@@ -158,6 +214,8 @@ func (c *Contact) attach(s1, s2 *Shape, detector IDetector) {
 	c.b1 = s1.rigidBody
 	c.b2 = s2.rigidBody
 	c.touching = false
+	c.passingOneWay = false
+	c.trigger = s1.trigger || s2.trigger
 	c._attachLinks()
 
 	c.detector = detector
@@ -168,7 +226,11 @@ func (c *Contact) attach(s1, s2 *Shape, detector IDetector) {
 func (c *Contact) detach() {
 	if c.touching {
 		// touching in the last frame
-		c._sendEndContact()
+		if c.trigger {
+			c._updateTrigger(false)
+		} else {
+			c._sendEndContact()
+		}
 	}
 
 	c._detachLinks()
@@ -185,16 +247,28 @@ func (c *Contact) detach() {
 }
 
 func (self *Contact) updateManifold() {
-	if self.detector == nil {
+	self.detect(self.detector)
+	self.sendEvents()
+}
+
+// Runs the narrow-phase collision detection with `detector` and updates the manifold. This touches nothing but the
+// contact, so contacts can be detected concurrently with a detector each. The events are sent by `sendEvents`.
+func (self *Contact) detect(detector IDetector) {
+	self.ptouching = self.touching
+	if detector == nil {
 		return
 	}
-
-	ptouching := self.touching
+	ptouching := self.ptouching
 
 	result := self.detectorResult
-	self.detector.Detect(result, self.s1.geom, self.s2.geom, &self.s1.transform, &self.s2.transform, self.cachedDetectorData)
+	detector.Detect(result, self.s1.geom, self.s2.geom, &self.s1.transform, &self.s2.transform, self.cachedDetectorData)
 
 	num := result.numPoints
+	if self.trigger {
+		// detectors also report separated points close to each other
+		self.touching = num > 0 && result.GetMaxDepth() >= 0
+		return
+	}
 	self.touching = num > 0
 
 	if self.touching {
@@ -210,6 +284,20 @@ func (self *Contact) updateManifold() {
 			self.contactConstraint.positionCorrectionAlgorithm = Settings.DefaultContactPositionCorrectionAlgorithm
 		}
 
+		// reset the material, surface, soft contact and modification parameters, which can be overridden in preSolve
+		self.contactConstraint.updateMaterial()
+		self.contactConstraint.updateSurface()
+		self.contactConstraint.updateSoftness()
+		self.contactConstraint.resetModifications()
+
+		// one-way shapes ignore the contact until the shapes stop touching
+		if !ptouching {
+			self.passingOneWay = self._isPassingOneWay(self.s1, self.s2, -1) || self._isPassingOneWay(self.s2, self.s1, 1)
+		}
+		if self.passingOneWay {
+			self.contactConstraint.enabled = false
+		}
+
 		// update contact manifold
 		if result.incremental {
 			// incremental manifold
@@ -220,8 +308,18 @@ func (self *Contact) updateManifold() {
 		}
 	} else {
 		self.manifold.clear()
+		self.passingOneWay = false
+	}
+}
+
+// Sends the events of the last `detect`.
+func (self *Contact) sendEvents() {
+	if self.trigger {
+		self._sendTriggerEvents()
+		return
 	}
 
+	ptouching := self.ptouching
 	if self.touching && !ptouching {
 		self._sendBeginContact()
 	}
@@ -250,11 +348,16 @@ func (self *Contact) GetShape2() *Shape {
 	return self.s2
 }
 
-// Returns whether the shapes are touching.
+// Returns whether the shapes are touching. For a trigger contact, this returns whether the shapes are overlapping.
 func (self *Contact) IsTouching() bool {
 	return self.touching
 }
 
+// Returns whether either shape of the contact is a trigger. A trigger contact has no manifold points.
+func (self *Contact) IsTrigger() bool {
+	return self.trigger
+}
+
 // Returns the contact manifold.
 func (self *Contact) GetManifold() *Manifold {
 	return self.manifold
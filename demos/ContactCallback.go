@@ -4,41 +4,39 @@ package demos
 // (oimo/dynamics/callback/ContactCallback.go)
 // A callback class for contact events. Contact events between two shapes will occur in following order:
 //
-// 1. `beginContact`
-// 2. `preSolve` (before velocity update)
-// 3. `postSolve` (after velocity update)
+// 1. `BeginContact`
+// 2. `PreSolve` (before velocity update)
+// 3. `PostSolve` (after velocity update)
 // 4. (repeats 2. and 3. every frame while the shapes are touching)
-// 5. `endContact`
+// 5. `EndContact`
 
 type IContactCallback interface {
 	// This is called when two shapes start touching each other. `c` is the contact of the two shapes.
-	beginContact(c *Contact)
+	BeginContact(c *Contact)
 	// This is called every frame **before** velocity solver iterations while two shapes are touching. `c` is the contact for the two shapes.
-	preSolve(c *Contact)
+	// The contact can be modified for the step here, see `ContactConstraint` and `ManifoldPoint.Disable`.
+	PreSolve(c *Contact)
 	// This is called every frame **after** velocity solver iterations while two shapes are touching. `c` is the contact for the two shapes.
-	postSolve(c *Contact)
+	PostSolve(c *Contact)
 	// This is called when two shapes end touching each other. `c` is the contact of the two shapes.
-	endContact(c *Contact)
+	EndContact(c *Contact)
 }
 
+// A contact callback whose methods do nothing. Embed this to implement only some of the methods of `IContactCallback`.
 type ContactCallback struct{}
 
 func NewContactCallback() *ContactCallback {
 	return &ContactCallback{}
 }
 
-func (cc *ContactCallback) beginContact(c *Contact) {
-	panic("abstract call")
+func (cc *ContactCallback) BeginContact(c *Contact) {
 }
 
-func (cc *ContactCallback) preSolve(c *Contact) {
-	panic("abstract call")
+func (cc *ContactCallback) PreSolve(c *Contact) {
 }
 
-func (cc *ContactCallback) postSolve(c *Contact) {
-	panic("abstract call")
+func (cc *ContactCallback) PostSolve(c *Contact) {
 }
 
-func (cc *ContactCallback) endContact(c *Contact) {
-	panic("abstract call")
+func (cc *ContactCallback) EndContact(c *Contact) {
 }
@@ -0,0 +1,61 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+// modifies the contacts by a function in PreSolve
+type preSolveModifier struct {
+	*ContactCallback
+	modify func(c *Contact)
+}
+
+func (m *preSolveModifier) PreSolve(c *Contact) {
+	m.modify(c)
+}
+
+func TestPreSolveContactModification(t *testing.T) {
+	w := groundTestWorld()
+	box := func(x float64, vel Vec3, modify func(c *Contact)) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x, 1, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		sc.Friction = 1
+		if modify != nil {
+			sc.ContactCallback = &preSolveModifier{modify: modify}
+		}
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		rb.SetLinearVelocity(vel)
+		return rb
+	}
+	disabled := box(-6, Vec3{}, func(c *Contact) {
+		c.GetContactConstraint().SetEnabled(false)
+	})
+	pointsDisabled := box(-3, Vec3{}, func(c *Contact) {
+		for _, p := range c.GetManifold().GetPoints()[:c.GetManifold().GetNumPoints()] {
+			p.Disable()
+		}
+	})
+	reference := box(3, Vec3{0, 0, 3}, nil)
+	frictionless := box(6, Vec3{0, 0, 3}, func(c *Contact) {
+		c.GetContactConstraint().SetFriction(0, 0)
+	})
+
+	stepWorld(w, 60)
+	if y := disabled.GetPosition().y; y > 0 {
+		t.Fatalf("the box of the disabled contact is held at %v", y)
+	}
+	if y := pointsDisabled.GetPosition().y; y > 0 {
+		t.Fatalf("the box of the disabled points is held at %v", y)
+	}
+	if v := reference.GetLinearVelocity(); v.z > 0.01 {
+		t.Fatalf("the reference box slides at %v", v.z)
+	}
+	if v := frictionless.GetLinearVelocity(); math.Abs(v.z-3) > 0.01 {
+		t.Fatalf("the frictionless box slides at %v", v.z)
+	}
+}
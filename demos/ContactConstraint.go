@@ -8,14 +8,34 @@ type ContactConstraint struct {
 
 	manifold *Manifold
 
-	s1          *Shape
-	s2          *Shape
-	tf1         *Transform
-	tf2         *Transform
-	invM1       float64
-	invM2       float64
-	friction    float64
-	restitution float64
+	s1             *Shape
+	s2             *Shape
+	tf1            *Transform
+	tf2            *Transform
+	invM1          float64
+	invM2          float64
+	friction       float64 // dynamic friction
+	staticFriction float64
+	restitution    float64
+
+	rollingFriction   float64
+	torsionalFriction float64
+
+	// scales of the friction along the tangent and the binormal
+	frictionScaleT float64
+	frictionScaleB float64
+
+	// target relative velocity of the first body to the second body along the contact plane, in world coordinates
+	targetVelocity Vec3
+
+	// per-step modifications, reset before preSolve
+	enabled       bool
+	invMassScale1 float64
+	invMassScale2 float64
+
+	// soft contact parameters, the contact is rigid if both are zero
+	stiffness float64
+	damping   float64
 
 	invI1 *Mat3
 	invI2 *Mat3
@@ -49,6 +69,77 @@ func (cc *ContactConstraint) detach() {
 	cc.s1, cc.s2, cc.b1, cc.b2, cc.tf1, cc.tf2 = nil, nil, nil, nil, nil, nil
 }
 
+// Combines the materials of the shapes, or looks up the material pair in the world.
+func (self *ContactConstraint) updateMaterial() {
+	m1 := self.s1.material
+	m2 := self.s2.material
+	if pair := self.b1.world.GetMaterialPair(m1, m2); pair != nil {
+		self.staticFriction = pair.StaticFriction
+		self.friction = pair.DynamicFriction
+		self.restitution = pair.Restitution
+		self.rollingFriction = pair.RollingFriction
+		self.torsionalFriction = pair.TorsionalFriction
+		return
+	}
+	frictionMode := max(m1.FrictionCombineMode, m2.FrictionCombineMode)
+	restitutionMode := max(m1.RestitutionCombineMode, m2.RestitutionCombineMode)
+	self.staticFriction = _combineCoefficients(frictionMode, m1.StaticFriction, m2.StaticFriction)
+	self.friction = _combineCoefficients(frictionMode, m1.DynamicFriction, m2.DynamicFriction)
+	self.restitution = _combineCoefficients(restitutionMode, m1.Restitution, m2.Restitution)
+	self.rollingFriction = _combineCoefficients(frictionMode, m1.RollingFriction, m2.RollingFriction)
+	self.torsionalFriction = _combineCoefficients(frictionMode, m1.TorsionalFriction, m2.TorsionalFriction)
+}
+
+// Aligns the friction basis with the friction direction of the shapes, and computes the relative velocity of the
+// surfaces.
+func (self *ContactConstraint) updateSurface() {
+	self.frictionScaleT = 1
+	self.frictionScaleB = 1
+	for _, s := range [2]*Shape{self.s1, self.s2} {
+		if !s.frictionDirection.IsZero() {
+			self.manifold.alignBasis(s.frictionDirection.MulMat3(&s.transform.rotation))
+			self.frictionScaleT = s.frictionScale1
+			self.frictionScaleB = s.frictionScale2
+			break
+		}
+	}
+
+	// the friction drags the first body along the surface of the second body and vice versa
+	self.targetVelocity = self.s2.surfaceVelocity.MulMat3(&self.s2.transform.rotation)
+	self.targetVelocity.SubEq(self.s1.surfaceVelocity.MulMat3(&self.s1.transform.rotation))
+}
+
+// Enables the contact and all of its manifold points, and resets the inverse mass scales.
+func (self *ContactConstraint) resetModifications() {
+	self.enabled = true
+	self.invMassScale1 = 1
+	self.invMassScale2 = 1
+	for _, p := range self.manifold.points {
+		p.ignored = false
+	}
+}
+
+// Combines the soft contact parameters of the shapes. A rigid shape takes no part, and two soft shapes act as
+// springs and dampers in series.
+func (self *ContactConstraint) updateSoftness() {
+	self.stiffness = _combineInSeries(self.s1.contactStiffness, self.s2.contactStiffness)
+	self.damping = _combineInSeries(self.s1.contactDamping, self.s2.contactDamping)
+}
+
+func _combineInSeries(a, b float64) float64 {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 {
+		return a
+	}
+	return a * b / (a + b)
+}
+
+func (self *ContactConstraint) isSoft() bool {
+	return self.stiffness > 0 || self.damping > 0
+}
+
 func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *ContactSolverInfo) {
 	info.b1 = self.b1
 	info.b2 = self.b2
@@ -57,11 +148,21 @@ func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *Co
 	tangent := self.manifold.tangent
 	binormal := self.manifold.binormal
 
-	friction := MathUtil.Sqrt(self.s1.friction * self.s2.friction)
-	restitution := MathUtil.Sqrt(self.s1.restitution * self.s2.restitution)
+	// convert the stiffness and the damping into ERP and CFM
+	soft := self.isSoft()
+	erp := 0.0
+	cfm := 0.0
+	if soft {
+		hk := timeStep.Dt * self.stiffness
+		erp = hk / (hk + self.damping)
+		cfm = timeStep.InvDt / (hk + self.damping)
+	}
 
 	num := self.manifold.numPoints
 	info.numRows = 0
+	if !self.enabled {
+		num = 0
+	}
 
 	// unused
 	// posDiff := self.tf1.position.Sub(self.tf2.position)
@@ -69,13 +170,13 @@ func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *Co
 	for i := range num {
 		p := self.manifold.points[i]
 
-		if p.depth < 0 {
+		if p.depth < 0 || p.ignored {
 			p.disabled = true
 
 			// clear accumulated impulses
 			p.impulse.clear()
 
-			// skip separated points
+			// skip separated or ignored points
 			continue
 		} else {
 			p.disabled = false
@@ -84,8 +185,15 @@ func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *Co
 		row := info.rows[info.numRows]
 		info.numRows++
 
-		row.friction = friction
-		row.cfm = 0 // TODO(Oimo): implement APIs for CFM setting?
+		row.cfm = cfm
+		row.rollingFriction = self.rollingFriction
+		row.torsionalFriction = self.torsionalFriction
+		row.frictionScaleT = self.frictionScaleT
+		row.frictionScaleB = self.frictionScaleB
+
+		// the friction drives the relative velocity to the target velocity
+		row.rhsT = self.targetVelocity.Dot(tangent)
+		row.rhsB = self.targetVelocity.Dot(binormal)
 
 		// set Jacobian
 		j := row.jacobianN
@@ -112,13 +220,19 @@ func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *Co
 
 		// disable bounce for warm-started contacts
 		if rvn < -Settings.ContactEnableBounceThreshold && !p.warmStarted {
-			row.rhs = -rvn * restitution
+			row.rhs = -rvn * self.restitution
 		} else {
 			row.rhs = 0
 		}
 
-		// set minimum RHS for baumgarte position correction
-		if self.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+		if soft {
+			// soft contacts correct the whole depth by ERP, and are never pushed out by the position solvers
+			minRhs := p.depth * erp * timeStep.InvDt
+			if row.rhs < minRhs {
+				row.rhs = minRhs
+			}
+		} else if self.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+			// set minimum RHS for baumgarte position correction
 			if p.depth > Settings.LinearSlop {
 				minRhs := (p.depth - Settings.LinearSlop) * Settings.VelocityBaumgarte * timeStep.InvDt
 				if row.rhs < minRhs {
@@ -127,11 +241,18 @@ func (self *ContactConstraint) getVelocitySolverInfo(timeStep TimeStep, info *Co
 			}
 		}
 
-		// reset impulses if warm starting is disabled
-		if !p.warmStarted {
+		// reset impulses if warm starting is disabled, sub-steps keep the impulses of the last sub-step
+		if !p.warmStarted && timeStep.subStep == 0 {
 			p.impulse.clear()
 		}
 
+		// use static friction until the point starts sliding
+		if p.impulse.sliding {
+			row.friction = self.friction
+		} else {
+			row.friction = self.staticFriction
+		}
+
 		row.impulse = &p.impulse
 	}
 }
@@ -144,6 +265,11 @@ func (self *ContactConstraint) getPositionSolverInfo(info *ContactSolverInfo) {
 
 	num := self.manifold.numPoints
 	info.numRows = 0
+	if !self.enabled {
+		num = 0
+	}
+
+	soft := self.isSoft()
 
 	for i := range num {
 		p := self.manifold.points[i]
@@ -162,8 +288,9 @@ func (self *ContactConstraint) getPositionSolverInfo(info *ContactSolverInfo) {
 		j.ang1 = p.relPos1.Cross(normal)
 		j.ang2 = p.relPos2.Cross(normal)
 
+		// soft contacts are corrected only in the velocity solver
 		row.rhs = p.depth - Settings.LinearSlop
-		if row.rhs < 0 {
+		if row.rhs < 0 || soft {
 			row.rhs = 0
 		}
 
@@ -193,6 +320,93 @@ func (self *ContactConstraint) getManifold() *Manifold {
 	return self.manifold
 }
 
+// Returns the stiffness of the contact. See `ShapeConfig.ContactStiffness` for details.
+func (self *ContactConstraint) GetStiffness() float64 {
+	return self.stiffness
+}
+
+// Sets the stiffness of the contact to `stiffness` for this step. This is reset to the combined stiffness of the
+// shapes every step, so call this in `IContactCallback.PreSolve` to override it.
+func (self *ContactConstraint) SetStiffness(stiffness float64) {
+	self.stiffness = stiffness
+}
+
+// Returns the damping of the contact. See `ShapeConfig.ContactDamping` for details.
+func (self *ContactConstraint) GetDamping() float64 {
+	return self.damping
+}
+
+// Sets the damping of the contact to `damping` for this step. This is reset to the combined damping of the shapes
+// every step, so call this in `IContactCallback.PreSolve` to override it.
+func (self *ContactConstraint) SetDamping(damping float64) {
+	self.damping = damping
+}
+
+// Returns whether the contact is enabled.
+func (self *ContactConstraint) IsEnabled() bool {
+	return self.enabled
+}
+
+// Sets whether the contact is enabled for this step. A disabled contact applies no impulses and does not connect the
+// rigid bodies into an island. This is reset to `true` every step, so call this in `IContactCallback.PreSolve`.
+func (self *ContactConstraint) SetEnabled(enabled bool) {
+	self.enabled = enabled
+}
+
+// Returns the coefficient of static friction of the contact.
+func (self *ContactConstraint) GetStaticFriction() float64 {
+	return self.staticFriction
+}
+
+// Returns the coefficient of dynamic friction of the contact.
+func (self *ContactConstraint) GetDynamicFriction() float64 {
+	return self.friction
+}
+
+// Sets the coefficients of static and dynamic friction of the contact for this step. These are reset to the
+// combined friction of the materials every step, so call this in `IContactCallback.PreSolve` to override them.
+func (self *ContactConstraint) SetFriction(staticFriction, dynamicFriction float64) {
+	self.staticFriction = staticFriction
+	self.friction = dynamicFriction
+}
+
+// Returns the coefficient of restitution of the contact.
+func (self *ContactConstraint) GetRestitution() float64 {
+	return self.restitution
+}
+
+// Sets the coefficient of restitution of the contact for this step. This is reset to the combined restitution of
+// the materials every step, so call this in `IContactCallback.PreSolve` to override it.
+func (self *ContactConstraint) SetRestitution(restitution float64) {
+	self.restitution = restitution
+}
+
+// Returns the target velocity of the contact. See `ContactConstraint.SetTargetVelocity` for details.
+func (self *ContactConstraint) GetTargetVelocity() Vec3 {
+	return self.targetVelocity
+}
+
+// Sets the target relative velocity of the first rigid body to the second rigid body along the contact plane, in
+// world coordinates, for this step. The friction drives the relative velocity of the contact points towards it, and
+// the component along the normal is ignored. This is reset to the difference of the surface velocities of the
+// shapes every step, so call this in `IContactCallback.PreSolve` to override it.
+func (self *ContactConstraint) SetTargetVelocity(targetVelocity Vec3) {
+	self.targetVelocity = targetVelocity
+}
+
+// Returns the scales of the inverse masses and inertias of the rigid bodies seen by the contact.
+func (self *ContactConstraint) GetInvMassScales() (scale1, scale2 float64) {
+	return self.invMassScale1, self.invMassScale2
+}
+
+// Scales the inverse masses and inertias of the first and the second rigid body seen by the contact for this step.
+// Set `0.0` to make a rigid body immovable by the contact, or a value greater than `1.0` to make it lighter. These
+// are reset to `1.0` every step, so call this in `IContactCallback.PreSolve`.
+func (self *ContactConstraint) SetInvMassScales(scale1, scale2 float64) {
+	self.invMassScale1 = scale1
+	self.invMassScale2 = scale2
+}
+
 // Returns whether the two rigid bodies are touching.
 func (cc *ContactConstraint) IsTouching() bool {
 	for i := range cc.manifold.numPoints {
@@ -0,0 +1,85 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoftContact(t *testing.T) {
+	w := groundTestWorld()
+	box := func(x, stiffness, damping float64) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x, 1, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		sc.ContactStiffness = stiffness
+		sc.ContactDamping = damping
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+	rigid := box(-2, 0, 0)
+	soft := box(2, 100, 10)
+
+	// the four corners of the soft box hold its weight like springs, so it sinks by m g / 4 k
+	stepWorld(w, 180)
+	sink := 1 - soft.GetPosition().y
+	if expected := 9.80665 / 400; math.Abs(sink-expected) > expected*0.2 {
+		t.Fatalf("the soft box sank by %v, expected %v", sink, expected)
+	}
+	if sink := 1 - rigid.GetPosition().y; sink > Settings.LinearSlop*2 {
+		t.Fatalf("the rigid box sank by %v", sink)
+	}
+}
+
+func TestAnisotropicFrictionAndSurfaceVelocity(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, nil)
+	ground := func(x float64, configure func(sc *ShapeConfig)) {
+		rc := NewRigidBodyConfig()
+		rc.Type = RigidBodyType_STATIC
+		rc.Position = Vec3{x, 0, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{10, 0.5, 10})
+		sc.Friction = 1
+		configure(sc)
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+	}
+	box := func(x, z float64, vel Vec3) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x, 1, z}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		rb.SetLinearVelocity(vel)
+		return rb
+	}
+
+	// grooves along x leave no friction along them, and a conveyor belt runs along z
+	ground(-10.5, func(sc *ShapeConfig) {
+		sc.FrictionDirection = Vec3{1, 0, 0}
+		sc.FrictionScale1 = 0
+		sc.FrictionScale2 = 1
+	})
+	ground(10.5, func(sc *ShapeConfig) {
+		sc.SurfaceVelocity = Vec3{0, 0, 2}
+	})
+	along := box(-15, -5, Vec3{3, 0, 0})
+	across := box(-10, -5, Vec3{0, 0, 3})
+	carried := box(10, -5, Vec3{})
+
+	stepWorld(w, 60)
+	if v := along.GetLinearVelocity(); math.Abs(v.x-3) > 0.01 {
+		t.Fatalf("the box sliding along the grooves moves at %v", v)
+	}
+	if v := across.GetLinearVelocity(); v.Length() > 0.01 {
+		t.Fatalf("the box sliding across the grooves moves at %v", v)
+	}
+	if v := carried.GetLinearVelocity(); math.Abs(v.z-2) > 0.01 || math.Abs(v.x) > 0.01 {
+		t.Fatalf("the box on the conveyor belt moves at %v", v)
+	}
+}
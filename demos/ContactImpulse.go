@@ -18,6 +18,19 @@ type ContactImpulse struct {
 
 	// lateral impulse
 	impulseL Vec3
+
+	// rolling angular impulses about the tangent and the binormal
+	impulseRT float64
+	impulseRB float64
+
+	// torsional angular impulse about the normal
+	impulseS float64
+
+	// rolling angular impulse
+	impulseR Vec3
+
+	// whether the surfaces were sliding in the last step
+	sliding bool
 }
 
 func NewContactImpulse() *ContactImpulse {
@@ -30,6 +43,11 @@ func (self *ContactImpulse) clear() {
 	self.impulseB = 0
 	self.impulseP = 0
 	self.impulseL.Zero()
+	self.impulseRT = 0
+	self.impulseRB = 0
+	self.impulseS = 0
+	self.impulseR.Zero()
+	self.sliding = false
 }
 
 // copyFrom() isn't copying impulseP, is this on purpose?
@@ -39,4 +57,9 @@ func (imp *ContactImpulse) copyFrom(other *ContactImpulse) {
 	imp.impulseB = other.impulseB
 	// TODO: Oimo code doesn't copy other.impulseP here, is this intentional?
 	imp.impulseL = other.impulseL
+	imp.impulseRT = other.impulseRT
+	imp.impulseRB = other.impulseRB
+	imp.impulseS = other.impulseS
+	imp.impulseR = other.impulseR
+	imp.sliding = other.sliding
 }
@@ -1,6 +1,12 @@
 package demos
 
-import "github.com/Salwan/goimo/debug"
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/Salwan/goimo/debug"
+)
 
 // ///////////////////////// ContactManager
 // (oimo/dynamics/ContactManager.go)
@@ -14,80 +20,121 @@ type ContactManager struct {
 
 	broadPhase      IBroadPhase
 	collisionMatrix *CollisionMatrix
+
+	// parallel narrow-phase, see `World.SetNumWorkers`
+	numWorkers          int
+	collisionMatrices   []*CollisionMatrix // per worker, as detectors keep working data
+	narrowPhaseContacts []*Contact
+
+	// see `World.SetDeterministic`
+	deterministic bool
+	sortedPairs   []*ProxyPair
 }
 
 func NewContactManager(broadPhase IBroadPhase) *ContactManager {
 	cm := &ContactManager{}
 	cm.broadPhase = broadPhase
 	cm.collisionMatrix = NewCollisionMatrix()
+	cm.numWorkers = 1
+	cm.collisionMatrices = []*CollisionMatrix{cm.collisionMatrix}
 	return cm
 }
 
 // --- private ---
 
 func (cm *ContactManager) _createContacts() {
-	for pp := cm.broadPhase.GetProxyPairList(); pp != nil; {
-		next := pp.next
+	if cm.deterministic {
+		cm._createContactsSorted()
+		return
+	}
+	for pp := cm.broadPhase.GetProxyPairList(); pp != nil; pp = pp.next {
+		cm._createContact(pp)
+	}
+}
 
-		var s1 *Shape
-		var s2 *Shape
-		if debug.Debug && pp.p1.GetID() == pp.p2.GetID() {
-			panic("OimoPhysics asserts here")
+// creates the contacts in the order of the shape ids rather than the order the broad-phase found the pairs in
+func (cm *ContactManager) _createContactsSorted() {
+	for pp := cm.broadPhase.GetProxyPairList(); pp != nil; pp = pp.next {
+		cm.sortedPairs = append(cm.sortedPairs, pp)
+	}
+	slices.SortFunc(cm.sortedPairs, func(a, b *ProxyPair) int {
+		a1, a2 := _pairShapeIds(a)
+		b1, b2 := _pairShapeIds(b)
+		if c := cmp.Compare(a1, b1); c != 0 {
+			return c
 		}
+		return cmp.Compare(a2, b2)
+	})
+	for _, pp := range cm.sortedPairs {
+		cm._createContact(pp)
+	}
+	clear(cm.sortedPairs)
+	cm.sortedPairs = cm.sortedPairs[:0]
+}
 
-		if pp.p1.GetID() < pp.p2.GetID() {
-			s1 = pp.p1.GetUserData().(*Shape)
-			s2 = pp.p2.GetUserData().(*Shape)
-		} else {
-			s1 = pp.p2.GetUserData().(*Shape)
-			s2 = pp.p1.GetUserData().(*Shape)
-		}
+// returns the ids of the shapes of the proxy pair, the lower one first
+func _pairShapeIds(pp *ProxyPair) (int, int) {
+	id1 := pp.p1.GetUserData().(*Shape).id
+	id2 := pp.p2.GetUserData().(*Shape).id
+	return min(id1, id2), max(id1, id2)
+}
 
-		// collision filtering
-		if !cm._shouldCollide(s1, s2) {
-			pp = next
-			continue
-		}
+func (cm *ContactManager) _createContact(pp *ProxyPair) {
+	var s1 *Shape
+	var s2 *Shape
+	if debug.Debug && pp.p1.GetID() == pp.p2.GetID() {
+		panic("OimoPhysics asserts here")
+	}
 
-		// search for the same contact
-		b1 := s1.rigidBody
-		b2 := s2.rigidBody
-		n1 := b1.numContactLinks
-		n2 := b2.numContactLinks
-		var l *ContactLink
-
-		// select shorter linked list
-		if n1 < n2 {
-			l = b1.contactLinkList
-		} else {
-			l = b2.contactLinkList
-		}
+	if pp.p1.GetID() < pp.p2.GetID() {
+		s1 = pp.p1.GetUserData().(*Shape)
+		s2 = pp.p2.GetUserData().(*Shape)
+	} else {
+		s1 = pp.p2.GetUserData().(*Shape)
+		s2 = pp.p1.GetUserData().(*Shape)
+	}
+
+	// collision filtering
+	if !cm._shouldCollide(s1, s2) {
+		return
+	}
 
-		id1, id2 := s1.id, s2.id
-		found := false
+	// search for the same contact
+	b1 := s1.rigidBody
+	b2 := s2.rigidBody
+	n1 := b1.numContactLinks
+	n2 := b2.numContactLinks
+	var l *ContactLink
 
-		for ; l != nil; l = l.next {
-			c := l.contact
-			if c.s1.id == id1 && c.s2.id == id2 {
-				// the same contact found
-				c.latest = true
-				found = true
-				break
-			}
-		}
+	// select shorter linked list
+	if n1 < n2 {
+		l = b1.contactLinkList
+	} else {
+		l = b2.contactLinkList
+	}
 
-		// if not found, create a new contact
-		if !found {
-			// trying to pick an object up from the pool
-			var c *Contact
-			cm.contactPool, c = SingleList_pick(cm.contactPool, NewContact)
-			cm.contactList, cm.contactListLast = DoubleList_push(cm.contactList, cm.contactListLast, c)
+	id1, id2 := s1.id, s2.id
+	found := false
+
+	for ; l != nil; l = l.next {
+		c := l.contact
+		if c.s1.id == id1 && c.s2.id == id2 {
+			// the same contact found
 			c.latest = true
-			c.attach(s1, s2, cm.collisionMatrix.GetDetector(s1.geom.GetType(), s2.geom.GetType()))
-			cm.numContacts++
+			found = true
+			break
 		}
+	}
 
-		pp = next
+	// if not found, create a new contact
+	if !found {
+		// trying to pick an object up from the pool
+		var c *Contact
+		cm.contactPool, c = SingleList_pick(cm.contactPool, NewContact)
+		cm.contactList, cm.contactListLast = DoubleList_push(cm.contactList, cm.contactListLast, c)
+		c.latest = true
+		c.attach(s1, s2, cm.collisionMatrix.GetDetector(s1.geom.GetType(), s2.geom.GetType()))
+		cm.numContacts++
 	}
 }
 
@@ -118,8 +165,8 @@ func (self *ContactManager) _destroyOutdatedContacts() {
 
 			active1 := !r1.sleeping && r1._type != RigidBodyType_STATIC
 			active2 := !r2.sleeping && r2._type != RigidBodyType_STATIC
-			if !active1 && !active2 {
-				// skip the pair if both rigid bodies are inactive
+			if !active1 && !active2 && !c.trigger {
+				// skip the pair if both rigid bodies are inactive, triggers keep detecting them
 				c.shouldBeSkipped = true
 				break
 			}
@@ -150,7 +197,12 @@ func (cm *ContactManager) _shouldCollide(s1, s2 *Shape) bool {
 		return false
 	}
 
-	if r1._type != RigidBodyType_DYNAMIC && r2._type != RigidBodyType_DYNAMIC {
+	if s1.trigger && s2.trigger {
+		// triggers don't detect each other
+		return false
+	}
+
+	if r1._type != RigidBodyType_DYNAMIC && r2._type != RigidBodyType_DYNAMIC && !s1.trigger && !s2.trigger {
 		// neither is dynamic
 		return false
 	}
@@ -160,6 +212,11 @@ func (cm *ContactManager) _shouldCollide(s1, s2 *Shape) bool {
 		return false
 	}
 
+	// articulation links don't collide with their parents
+	if l1, l2 := r1.articulationLink, r2.articulationLink; l1 != nil && l2 != nil && (l1.parent == l2 || l2.parent == l1) {
+		return false
+	}
+
 	// search for joints the two bodies are connected to
 	var jl *JointLink
 	var other *RigidBody
@@ -193,7 +250,7 @@ func (cm *ContactManager) updateContacts() {
 func (self *ContactManager) postSolve() {
 	for c := self.contactList; c != nil; {
 		next := c.next
-		if c.touching {
+		if c.touching && !c.trigger {
 			c.postSolve()
 		}
 		c = next
@@ -201,15 +258,142 @@ func (self *ContactManager) postSolve() {
 }
 
 func (self *ContactManager) updateManifolds() {
+	if self.numWorkers > 1 {
+		self.updateManifoldsParallel()
+		return
+	}
 	for c := self.contactList; c != nil; {
 		next := c.next
 		if !c.shouldBeSkipped {
 			c.updateManifold()
+		} else if c.trigger && c.touching {
+			// the AABBs are separated
+			c._updateTrigger(false)
 		}
 		c = next
 	}
 }
 
+// Detects the contacts in chunks on `numWorkers` goroutines, each with the detectors of its own collision matrix,
+// then sends the events in the order of the contact list.
+func (self *ContactManager) updateManifoldsParallel() {
+	for c := self.contactList; c != nil; c = c.next {
+		if !c.shouldBeSkipped {
+			self.narrowPhaseContacts = append(self.narrowPhaseContacts, c)
+		}
+	}
+
+	numContacts := len(self.narrowPhaseContacts)
+	numWorkers := min(self.numWorkers, numContacts)
+	var wg sync.WaitGroup
+	for w := range numWorkers {
+		wg.Add(1)
+		go func(matrix *CollisionMatrix, contacts []*Contact) {
+			defer wg.Done()
+			for _, c := range contacts {
+				if c.detector != nil {
+					c.detect(matrix.GetDetector(c.s1.geom.GetType(), c.s2.geom.GetType()))
+				} else {
+					c.detect(nil)
+				}
+			}
+		}(self.collisionMatrices[w], self.narrowPhaseContacts[numContacts*w/numWorkers:numContacts*(w+1)/numWorkers])
+	}
+	wg.Wait()
+
+	clear(self.narrowPhaseContacts)
+	self.narrowPhaseContacts = self.narrowPhaseContacts[:0]
+
+	for c := self.contactList; c != nil; {
+		next := c.next
+		if !c.shouldBeSkipped {
+			c.sendEvents()
+		} else if c.trigger && c.touching {
+			// the AABBs are separated
+			c._updateTrigger(false)
+		}
+		c = next
+	}
+}
+
+// Saves the contacts in the order of the contact list.
+func (self *ContactManager) save(snapshot *WorldSnapshot) {
+	for c := self.contactList; c != nil; c = c.next {
+		cs := contactSnapshot{
+			s1:              c.s1,
+			s2:              c.s2,
+			latest:          c.latest,
+			shouldBeSkipped: c.shouldBeSkipped,
+			touching:        c.touching,
+			ptouching:       c.ptouching,
+			passingOneWay:   c.passingOneWay,
+			manifold:        *c.manifold,
+			constraint:      *c.contactConstraint,
+		}
+		if c.cachedDetectorData.gjkCache != nil {
+			gjkCache := *c.cachedDetectorData.gjkCache
+			cs.gjkCache = &gjkCache
+		}
+		cs.points = make([]ManifoldPoint, c.manifold.numPoints)
+		for i := range c.manifold.numPoints {
+			cs.points[i] = *c.manifold.points[i]
+		}
+		snapshot.contacts = append(snapshot.contacts, cs)
+	}
+}
+
+// Replaces the contacts with the ones saved in `snapshot`, without sending any contact events. Creating them in the
+// saved order also restores the order of the contact links of the rigid bodies.
+func (self *ContactManager) restore(snapshot *WorldSnapshot) {
+	for self.contactList != nil {
+		c := self.contactList
+		c.touching = false // no end events
+		self.destroyContact(c)
+	}
+
+	for i := range snapshot.contacts {
+		cs := &snapshot.contacts[i]
+		var c *Contact
+		self.contactPool, c = SingleList_pick(self.contactPool, NewContact)
+		self.contactList, self.contactListLast = DoubleList_push(self.contactList, self.contactListLast, c)
+		c.attach(cs.s1, cs.s2, self.collisionMatrix.GetDetector(cs.s1.geom.GetType(), cs.s2.geom.GetType()))
+		self.numContacts++
+
+		c.latest = cs.latest
+		c.shouldBeSkipped = cs.shouldBeSkipped
+		c.touching = cs.touching
+		c.ptouching = cs.ptouching
+		c.passingOneWay = cs.passingOneWay
+		if cs.gjkCache != nil {
+			if c.cachedDetectorData.gjkCache == nil {
+				c.cachedDetectorData.gjkCache = NewGjkCache()
+			}
+			*c.cachedDetectorData.gjkCache = *cs.gjkCache
+		}
+
+		// keep the points and the solver of the contact
+		m := c.manifold
+		points := m.points
+		*m = cs.manifold
+		m.points = points
+		for j := range cs.points {
+			*m.points[j] = cs.points[j]
+		}
+		cc := c.contactConstraint
+		solver := cc.solver
+		*cc = cs.constraint
+		cc.manifold = m
+		cc.solver = solver
+	}
+}
+
+func (self *ContactManager) setNumWorkers(numWorkers int) {
+	self.numWorkers = numWorkers
+	for len(self.collisionMatrices) < numWorkers {
+		self.collisionMatrices = append(self.collisionMatrices, NewCollisionMatrix())
+	}
+}
+
 func (self *ContactManager) destroyContact(contact *Contact) {
 	self.contactList, self.contactListLast = DoubleList_remove(self.contactList, self.contactListLast, contact)
 	contact.detach()
@@ -0,0 +1,117 @@
+package demos
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// records the trigger events as the first letters of their names
+type triggerRecorder struct {
+	*TriggerCallback
+	events []byte
+}
+
+func (r *triggerRecorder) TriggerEnter(trigger, other *Shape) {
+	r.events = append(r.events, 'e')
+}
+
+func (r *triggerRecorder) TriggerStay(trigger, other *Shape) {
+	r.events = append(r.events, 's')
+}
+
+func (r *triggerRecorder) TriggerExit(trigger, other *Shape) {
+	r.events = append(r.events, 'x')
+}
+
+// adds a box trigger shape of the rigid body type `_type` to `w`, and returns the body and the recorder of its events
+func addTrigger(w *World, _type RigidBodyType, position, halfExtents Vec3) (*RigidBody, *triggerRecorder) {
+	recorder := &triggerRecorder{}
+	rc := NewRigidBodyConfig()
+	rc.Type = _type
+	rc.Position = position
+	rb := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(halfExtents)
+	sc.Trigger = true
+	sc.TriggerCallback = recorder
+	rb.AddShape(NewShape(sc))
+	w.AddRigidBody(rb)
+	return rb, recorder
+}
+
+func TestTriggerEvents(t *testing.T) {
+	w := groundTestWorld()
+	_, recorder := addTrigger(w, RigidBodyType_STATIC, Vec3{0, 3, 0}, Vec3{1, 1, 1})
+	box := func(x float64) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x, 6, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+	falling := box(0)
+	reference := box(5)
+
+	// the box falls through the trigger to the ground as freely as the reference box beside it
+	for range 90 {
+		stepWorld(w, 1)
+		if math.Abs(falling.GetPosition().y-reference.GetPosition().y) > 1e-9 {
+			t.Fatalf("the trigger moved the box to %v, the reference box is at %v", falling.GetPosition(), reference.GetPosition())
+		}
+	}
+	if y := falling.GetPosition().y; math.Abs(y-1) > Settings.LinearSlop*2 {
+		t.Fatalf("the box didn't land on the ground: %v", y)
+	}
+	events := string(recorder.events)
+	if len(events) < 3 || events != "e"+strings.Repeat("s", len(events)-2)+"x" {
+		t.Fatalf("unexpected trigger events %q", events)
+	}
+}
+
+func TestTriggerDetectsInactiveBodies(t *testing.T) {
+	w := groundTestWorld()
+
+	// a kinematic trigger sweeps over a static box, which neither body would collide with otherwise
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	rc.Position = Vec3{0, 5, 0}
+	static := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	static.AddShape(NewShape(sc))
+	w.AddRigidBody(static)
+	sweeper, sweeps := addTrigger(w, RigidBodyType_KINEMATIC, Vec3{-3, 5, 0}, Vec3{1, 1, 1})
+	sweeper.SetLinearVelocity(Vec3{6, 0, 0})
+	stepWorld(w, 60)
+	if events := string(sweeps.events); len(events) < 3 || events[0] != 'e' || events[len(events)-1] != 'x' {
+		t.Fatalf("unexpected events of the kinematic trigger %q", events)
+	}
+
+	// a static trigger keeps detecting a box sleeping in it, until the box is removed
+	_, recorder := addTrigger(w, RigidBodyType_STATIC, Vec3{5, 1.6, 0}, Vec3{1, 1, 1})
+	rc = NewRigidBodyConfig()
+	rc.Position = Vec3{5, 1, 0}
+	sleeper := NewRigidBody(rc)
+	sleeper.AddShape(NewShape(sc))
+	w.AddRigidBody(sleeper)
+	for i := 0; !sleeper.IsSleeping(); i++ {
+		if i == 600 {
+			t.Fatal("the box doesn't fall asleep")
+		}
+		stepWorld(w, 1)
+	}
+	numEvents := len(recorder.events)
+	stepWorld(w, 10)
+	if events := string(recorder.events[numEvents:]); events != strings.Repeat("s", 10) {
+		t.Fatalf("unexpected events while the box sleeps %q", events)
+	}
+	w.RemoveRigidBody(sleeper)
+	stepWorld(w, 1)
+	if events := string(recorder.events); events[0] != 'e' || events[len(events)-1] != 'x' || strings.Count(events, "x") != 1 {
+		t.Fatalf("unexpected events %q", events)
+	}
+}
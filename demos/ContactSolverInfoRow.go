@@ -23,6 +23,19 @@ type ContactSolverInfoRow struct {
 	// Used for velocity solver.
 	friction float64
 
+	// Used for velocity solver. The scales of the friction along the tangent and the binormal.
+	frictionScaleT float64
+	frictionScaleB float64
+
+	// Used for velocity solver. The target relative velocities along the tangent and the binormal.
+	rhsT float64
+	rhsB float64
+
+	// Used for velocity solver. The angular rows about the tangent and the binormal resist rolling, and the angular
+	// row about the normal resists spinning.
+	rollingFriction   float64
+	torsionalFriction float64
+
 	// Used for both velocity and position solver.
 	impulse *ContactImpulse
 }
@@ -40,5 +53,7 @@ func (self *ContactSolverInfoRow) Clear() {
 	self.jacobianT.Clear()
 	self.jacobianB.Clear()
 	self.rhs, self.cfm, self.friction = 0.0, 0.0, 0.0
+	self.rollingFriction, self.torsionalFriction = 0.0, 0.0
+	self.frictionScaleT, self.frictionScaleB, self.rhsT, self.rhsB = 0.0, 0.0, 0.0, 0.0
 	self.impulse = nil
 }
@@ -23,6 +23,14 @@ type ContactSolverMassDataRow struct {
 	invMAngB1 Vec3
 	invMAngB2 Vec3
 
+	// rolling and torsional angular impulse -> angular velocity change
+	invMAngRT1 Vec3
+	invMAngRT2 Vec3
+	invMAngRB1 Vec3
+	invMAngRB2 Vec3
+	invMAngS1  Vec3
+	invMAngS2  Vec3
+
 	// normal mass
 	massN float64
 
@@ -31,6 +39,13 @@ type ContactSolverMassDataRow struct {
 	massTB01 float64
 	massTB10 float64
 	massTB11 float64
+
+	// rolling mass matrix and torsional mass
+	massR00 float64
+	massR01 float64
+	massR10 float64
+	massR11 float64
+	massS   float64
 }
 
 func NewContactSolverMassDataRow() *ContactSolverMassDataRow {
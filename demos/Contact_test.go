@@ -0,0 +1,41 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOneWayPlatform(t *testing.T) {
+	w := groundTestWorld()
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	rc.Position = Vec3{0, 3, 0}
+	platform := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{2, 0.1, 2})
+	sc.OneWayNormal = Vec3{0, 1, 0}
+	platform.AddShape(NewShape(sc))
+	w.AddRigidBody(platform)
+
+	rc = NewRigidBodyConfig()
+	rc.Position = Vec3{0, 1, 0}
+	box := NewRigidBody(rc)
+	sc = NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	box.AddShape(NewShape(sc))
+	w.AddRigidBody(box)
+
+	// the box jumps up through the platform to about 4.2, and lands on it
+	box.SetLinearVelocity(Vec3{0, 8, 0})
+	maxY := 0.0
+	for range 120 {
+		stepWorld(w, 1)
+		maxY = max(maxY, box.GetPosition().y)
+	}
+	if maxY < 4 {
+		t.Fatalf("the box was stopped by the platform at %v", maxY)
+	}
+	if y := box.GetPosition().y; math.Abs(y-3.6) > Settings.LinearSlop*2 {
+		t.Fatalf("the box ended at %v", y)
+	}
+}
@@ -0,0 +1,202 @@
+package demos
+
+//////////////////////////////////////////////// CylindricalJoint
+// (oimo/dynamics/constraint/joint/CylindricalJoint.go)
+// A cylindrical joint constrains two rigid bodies to share their constraint axes, and restricts relative translation and
+// rotation onto the constraint axis. This joint provides two degrees of freedom. You can enable lower and upper limits,
+// motors, spring and damper effects of both translation and rotation part of the constraint.
+
+type CylindricalJoint struct {
+	*Joint
+
+	translSd *SpringDamper
+	translLm *TranslationalLimitMotor
+	rotSd    *SpringDamper
+	rotLm    *RotationalLimitMotor
+
+	translation  float64
+	angle        float64
+	linearErrorY float64
+	linearErrorZ float64
+	swingError   Vec3
+}
+
+// Creates a new cylindrical joint by configuration `config`.
+func NewCylindricalJoint(config *CylindricalJointConfig) *CylindricalJoint {
+	j := &CylindricalJoint{
+		Joint:    NewJoint(config.JointConfig, JointType_CYLINDRICAL),
+		translSd: config.TranslationalSpringDamper.Clone(),
+		translLm: config.TranslationalLimitMotor.Clone(),
+		rotSd:    config.RotationalSpringDamper.Clone(),
+		rotLm:    config.RotationalLimitMotor.Clone(),
+	}
+	j.impl = j
+
+	j.localBasisX1 = config.LocalAxis1
+	j.localBasisX2 = config.LocalAxis2
+	j.buildLocalBasesFromX()
+
+	return j
+}
+
+func (self *CylindricalJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	// compute rhs
+	linRhsY := self.linearErrorY * erp
+	linRhsZ := self.linearErrorZ * erp
+	angRhsY := self.swingError.Dot(self.basisY1) * erp
+	angRhsZ := self.swingError.Dot(self.basisZ1) * erp
+
+	translMotorMass := self.b1.invMass + self.b2.invMass
+	if translMotorMass != 0 {
+		translMotorMass = 1 / translMotorMass
+	}
+	rotMotorMass := self.computeEffectiveInertiaMoment(self.basisX1)
+
+	// linear X
+	if self.translSd.Frequency <= 0 || !isPositionPart {
+		row := info.AddRow(&self.impulses[0])
+		self.setSolverInfoRowLinear(row, self.translation, self.translLm, translMotorMass, self.translSd, timeStep, isPositionPart)
+		self.setJacobianLinear(row.jacobian, self.basisX1)
+	}
+
+	// linear Y
+	row := info.AddRow(&self.impulses[1])
+	row.EqualLimit(linRhsY, 0)
+	self.setJacobianLinear(row.jacobian, self.basisY1)
+
+	// linear Z
+	row = info.AddRow(&self.impulses[2])
+	row.EqualLimit(linRhsZ, 0)
+	self.setJacobianLinear(row.jacobian, self.basisZ1)
+
+	// angular X
+	if self.rotSd.Frequency <= 0 || !isPositionPart {
+		row = info.AddRow(&self.impulses[3])
+		self.setSolverInfoRowAngular(row, self.angle, self.rotLm, rotMotorMass, self.rotSd, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.basisX1)
+	}
+
+	// angular Y
+	row = info.AddRow(&self.impulses[4])
+	row.EqualLimit(angRhsY, 0)
+	self.setJacobianAngular(row.jacobian, self.basisY1)
+
+	// angular Z
+	row = info.AddRow(&self.impulses[5])
+	row.EqualLimit(angRhsZ, 0)
+	self.setJacobianAngular(row.jacobian, self.basisZ1)
+}
+
+func (self *CylindricalJoint) computeErrors() {
+	anchorDiff := self.anchor2.Sub(self.anchor1)
+	self.translation = anchorDiff.Dot(self.basisX1)
+	self.linearErrorY = anchorDiff.Dot(self.basisY1)
+	self.linearErrorZ = anchorDiff.Dot(self.basisZ1)
+	self.swingError, self.angle = self.computeSwingTwist()
+}
+
+// --- internal ---
+
+func (self *CylindricalJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *CylindricalJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *CylindricalJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first rigid body's constraint axis in world coordinates.
+func (self *CylindricalJoint) GetAxis1() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second rigid body's constraint axis in world coordinates.
+func (self *CylindricalJoint) GetAxis2() Vec3 {
+	return self.basisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *CylindricalJoint) GetAxis1To(axis *Vec3) {
+	*axis = self.basisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *CylindricalJoint) GetAxis2To(axis *Vec3) {
+	*axis = self.basisX2
+}
+
+// Returns the first rigid body's constraint axis relative to the rigid body's transform.
+func (self *CylindricalJoint) GetLocalAxis1() Vec3 {
+	return self.localBasisX1
+}
+
+// Returns the second rigid body's constraint axis relative to the rigid body's transform.
+func (self *CylindricalJoint) GetLocalAxis2() Vec3 {
+	return self.localBasisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *CylindricalJoint) GetLocalAxis1To(axis *Vec3) {
+	*axis = self.localBasisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *CylindricalJoint) GetLocalAxis2To(axis *Vec3) {
+	*axis = self.localBasisX2
+}
+
+// Returns the translational spring and damper settings.
+func (self *CylindricalJoint) GetTranslationalSpringDamper() *SpringDamper {
+	return self.translSd
+}
+
+// Returns the rotational spring and damper settings.
+func (self *CylindricalJoint) GetRotationalSpringDamper() *SpringDamper {
+	return self.rotSd
+}
+
+// Returns the translational limits and motor settings.
+func (self *CylindricalJoint) GetTranslationalLimitMotor() *TranslationalLimitMotor {
+	return self.translLm
+}
+
+// Returns the rotational limits and motor settings.
+func (self *CylindricalJoint) GetRotationalLimitMotor() *RotationalLimitMotor {
+	return self.rotLm
+}
+
+// Returns the rotation angle in radians.
+func (self *CylindricalJoint) GetAngle() float64 {
+	return self.angle
+}
+
+// Returns the translation of the joint.
+func (self *CylindricalJoint) GetTranslation() float64 {
+	return self.translation
+}
+
+// Returns the speed of the translation along the constraint axis, in meters per second.
+func (self *CylindricalJoint) GetTranslationalSpeed() float64 {
+	return self.computeTranslationalSpeed(self.basisX1)
+}
+
+// Returns the speed of the rotation about the constraint axis, in radians per second.
+func (self *CylindricalJoint) GetAngularSpeed() float64 {
+	return self.computeAngularSpeed(self.basisX1)
+}
@@ -0,0 +1,47 @@
+package demos
+
+//////////////////////////////////////////////// CylindricalJointConfig
+// (oimo/dynamics/constraint/joint/CylindricalJointConfig.go)
+// A cylindrical joint config is used for constructions of cylindrical joints.
+
+type CylindricalJointConfig struct {
+	*JointConfig
+
+	// The first body's local constraint axis.
+	LocalAxis1 Vec3
+
+	// The second body's local constraint axis.
+	LocalAxis2 Vec3
+
+	// The translational limit and motor along the constraint axis of the joint.
+	TranslationalLimitMotor *TranslationalLimitMotor
+
+	// The translational spring and damper along constraint the axis of the joint.
+	TranslationalSpringDamper *SpringDamper
+
+	// The rotational limit and motor along the constraint axis of the joint.
+	RotationalLimitMotor *RotationalLimitMotor
+
+	// The rotational spring and damper along the constraint axis of the joint.
+	RotationalSpringDamper *SpringDamper
+}
+
+func NewCylindricalJointConfig() *CylindricalJointConfig {
+	return &CylindricalJointConfig{
+		JointConfig:               NewJointConfig(),
+		LocalAxis1:                Vec3{1, 0, 0},
+		LocalAxis2:                Vec3{1, 0, 0},
+		TranslationalLimitMotor:   NewTranslationalLimitMotor(),
+		TranslationalSpringDamper: NewSpringDamper(),
+		RotationalLimitMotor:      NewRotationalLimitMotor(),
+		RotationalSpringDamper:    NewSpringDamper(),
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local axes from the world axis `worldAxis`, and returns `this`.
+func (self *CylindricalJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor, worldAxis Vec3) *CylindricalJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+	rigidBody1.GetLocalVectorTo(worldAxis, &self.LocalAxis1)
+	rigidBody2.GetLocalVectorTo(worldAxis, &self.LocalAxis2)
+	return self
+}
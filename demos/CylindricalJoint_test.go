@@ -0,0 +1,23 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCylindricalJointLimitsAndMotor(t *testing.T) {
+	w, base, box := jointTestWorld()
+	config := NewCylindricalJointConfig().Init(base, box, Vec3{}, Vec3{0, 1, 0})
+	config.TranslationalLimitMotor.SetLimits(0, 0.3).SetMotor(1, 100)
+	config.RotationalLimitMotor.SetMotor(2, 100)
+	j := NewCylindricalJoint(config)
+	w.AddJoint(j.Joint)
+
+	stepWorld(w, 60)
+	if tr := j.GetTranslation(); math.Abs(tr-0.3) > Settings.LinearSlop*2 {
+		t.Fatalf("stopped at %v", tr)
+	}
+	if s, as := j.GetTranslationalSpeed(), j.GetAngularSpeed(); math.Abs(s) > 1e-3 || math.Abs(as-2) > 1e-3 {
+		t.Fatalf("speeds %v and %v", s, as)
+	}
+}
@@ -85,11 +85,12 @@ func convVec3ToVector3(v *Vec3) math32.Vector3 {
 	return math32.Vector3{X: float32(v.x), Y: float32(v.y), Z: float32(v.z)}
 }
 
-// Synchronizes box with physical rigidbody
-func (db *DemoBox) sync() {
-	p := convVec3ToVector3(&db.rb.transform.position)
+// Synchronizes box with physical rigidbody, interpolated by `alpha` between the last two steps
+func (db *DemoBox) sync(alpha float64) {
+	tf := db.rb.GetInterpolatedTransform(alpha)
+	p := convVec3ToVector3(&tf.position)
 	var r Quat
-	r.fromMat3(&db.rb.transform.rotation)
+	r.fromMat3(&tf.rotation)
 	db.SetPositionVec(&p)
 	db.SetRotationQuat(&math32.Quaternion{X: float32(r.x), Y: float32(r.y), Z: float32(r.z), W: float32(r.w)})
 }
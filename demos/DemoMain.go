@@ -32,6 +32,12 @@ type DemoMain struct {
 	dt          float64
 	demoBoxes   []*DemoBox
 	paused      bool
+
+	// picking, shift + left mouse button drags a rigid body
+	orbit        *camera.OrbitControl
+	ground       *RigidBody
+	mouseJoint   *MouseJoint
+	pickFraction float64
 }
 
 func NewDemoMain() *DemoMain {
@@ -79,8 +85,13 @@ func NewDemoMain() *DemoMain {
 	dm.initBaseDemo()
 	dm.initBasicDemo()
 
+	// Picking, subscribed before the orbit control so it's disabled before it sees the mouse button
+	gui.Manager().Subscribe(window.OnMouseDown, dm.onMouseDown)
+	gui.Manager().Subscribe(window.OnMouseUp, dm.onMouseUp)
+	dm.application.Subscribe(window.OnCursor, dm.onCursor)
+
 	// TEMP: orbit camera to see what's going on
-	camera.NewOrbitControl(dm.cam)
+	dm.orbit = camera.NewOrbitControl(dm.cam)
 
 	// Start paused
 	dm.paused = true
@@ -99,8 +110,9 @@ func (dm *DemoMain) initBasicDemo() {
 	dm.cam.LookAt(math32.NewVector3(0, 2, 0), math32.NewVector3(0, 1, 0))
 
 	thickness := 0.5
-	_, gground := OimoUtil.AddBox(dm.world, &Vec3{0, -thickness, 0},
+	ground, gground := OimoUtil.AddBox(dm.world, &Vec3{0, -thickness, 0},
 		&Vec3{7, thickness, 7}, true)
+	dm.ground = ground
 	dm.root.Add(gground)
 	dm.demoBoxes = append(dm.demoBoxes, gground)
 
@@ -127,6 +139,67 @@ OuterLoop:
 	}
 }
 
+// returns the line segment from the near plane to the far plane under the screen position (`x`, `y`)
+func (dm *DemoMain) screenRay(x, y float32) (begin, end Vec3) {
+	nx := 2*x/float32(dm.width) - 1
+	ny := 1 - 2*y/float32(dm.height)
+	near := math32.NewVector3(nx, ny, -1)
+	far := math32.NewVector3(nx, ny, 1)
+	dm.cam.Unproject(near)
+	dm.cam.Unproject(far)
+	begin = Vec3{float64(near.X), float64(near.Y), float64(near.Z)}
+	end = Vec3{float64(far.X), float64(far.Y), float64(far.Z)}
+	return
+}
+
+func (dm *DemoMain) onMouseDown(evname string, ev interface{}) {
+	mev := ev.(*window.MouseEvent)
+	if mev.Button != window.MouseButtonLeft || mev.Mods&window.ModShift == 0 || dm.mouseJoint != nil {
+		return
+	}
+
+	begin, end := dm.screenRay(mev.Xpos, mev.Ypos)
+	closest := NewRayCastClosest()
+	dm.world.RayCast(begin, end, closest)
+	if !closest.Hit {
+		return
+	}
+	rb := closest.Shape.GetRigidBody()
+	if rb.GetType() != RigidBodyType_DYNAMIC {
+		return
+	}
+
+	config := NewMouseJointConfig().Init(dm.ground, rb, closest.Position)
+	config.MaxForce = 1000 * rb.GetMass()
+	dm.mouseJoint = NewMouseJoint(config)
+	dm.world.AddJoint(dm.mouseJoint.Joint)
+	dm.pickFraction = closest.Fraction
+
+	// don't orbit while dragging
+	dm.orbit.SetEnabled(camera.OrbitNone)
+}
+
+func (dm *DemoMain) onMouseUp(evname string, ev interface{}) {
+	if dm.mouseJoint == nil {
+		return
+	}
+	dm.world.RemoveJoint(dm.mouseJoint.Joint)
+	dm.mouseJoint = nil
+	dm.orbit.SetEnabled(camera.OrbitAll)
+}
+
+func (dm *DemoMain) onCursor(evname string, ev interface{}) {
+	if dm.mouseJoint == nil {
+		return
+	}
+	cev := ev.(*window.CursorEvent)
+
+	// keep the picked depth along the ray
+	begin, end := dm.screenRay(cev.Xpos, cev.Ypos)
+	dir := end.Sub(begin)
+	dm.mouseJoint.SetTarget(begin.AddRhsScaled(dir, dm.pickFraction))
+}
+
 func (dm *DemoMain) Run() {
 	dm.application.Run(func(render *renderer.Renderer, deltaTime time.Duration) {
 		dt := float32(deltaTime.Seconds())
@@ -148,23 +221,23 @@ func (dm *DemoMain) Update(dt float32) {
 		time.Sleep(100 * time.Millisecond)
 	}
 
-	// synchronize boxes
-	for _, b := range dm.demoBoxes {
-		b.sync()
-	}
-
 	// DemoBase: update() doesnt run when in demo
 	// DemoMain: loop() runs at 60fps (called from javascript)
 	// BasicDemo (currentDemo): nothing relevant
 	// currentDemo.update(): nothing relevant
 
-	// Hardcode fixed timestep for testing
-	st := 1.0 / 60.0
-	if dm.paused && !step {
-		st = 0.0
+	// fixed steps, a single step at a time when paused
+	alpha := 1.0
+	if !dm.paused {
+		dm.world.Advance(float64(dt))
+		alpha = dm.world.GetInterpolationAlpha()
+	} else if step {
+		dm.world.Step(dm.world.GetFixedTimeStep())
 	}
-	if st > 0.0 {
-		dm.world.Step(st) //dm.dt)
+
+	// synchronize boxes
+	for _, b := range dm.demoBoxes {
+		b.sync(alpha)
 	}
 }
 
@@ -51,4 +51,277 @@ func NewDirectJointConstraintSolver(joint *Joint) *DirectJointConstraintSolver {
 	return d
 }
 
-// TODO
+func (self *DirectJointConstraintSolver) PreSolveVelocity(timeStep TimeStep) {
+	self.joint.impl.syncAnchors()
+	self.joint.impl.getVelocitySolverInfo(timeStep, self.info)
+
+	self.b1 = self.info.b1
+	self.b2 = self.info.b2
+
+	self.massMatrix.computeInvMass(self.info, self.massData)
+
+	// build boundaries
+	self.boundaryBuilder.buildBoundaries(self.info)
+
+	// update the size of the boundary selector
+	self.velBoundarySelector.setSize(self.boundaryBuilder.numBoundaries)
+}
+
+func (self *DirectJointConstraintSolver) WarmStart(timeStep TimeStep) {
+	var factor float64
+	if self.joint.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+		factor = Settings.JointWarmStartingFactorForBaungarte
+	} else {
+		factor = Settings.JointWarmStartingFactor
+	}
+
+	// adjust impulse for variable time step
+	factor *= timeStep.DtRatio
+
+	// warm starting disabled
+	if factor <= 0 {
+		for i := range self.info.numRows {
+			self.info.rows[i].impulse.Clear()
+		}
+		return
+	}
+
+	lv1 := self.b1.vel
+	lv2 := self.b2.vel
+	av1 := self.b1.angVel
+	av2 := self.b2.angVel
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		imp := row.impulse
+		md := self.massData[i]
+
+		// update limit impulse
+		imp.impulse *= factor
+
+		// update motor impulse
+		imp.impulseM *= factor
+
+		impulse := imp.impulse + imp.impulseM
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, row.jacobian, md, impulse)
+	}
+
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
+}
+
+func (self *DirectJointConstraintSolver) SolveVelocity() {
+	numRows := self.info.numRows
+
+	lv1 := self.b1.vel
+	lv2 := self.b2.vel
+	av1 := self.b1.angVel
+	av2 := self.b2.angVel
+
+	for i := range numRows {
+		row := self.info.rows[i]
+
+		// compute relative velocity
+		self.relVels[i] = _measureJointRelVel(&lv1, &lv2, &av1, &av2, row.jacobian)
+
+		// get impulse
+		self.impulses[i] = row.impulse.impulse
+
+		// clear total impulse
+		self.dTotalImpulses[i] = 0
+	}
+
+	// solve motors first
+	invMassWithoutCfm := self.massMatrix.invMassWithoutCfm
+	for i := range numRows {
+		row := self.info.rows[i]
+		imp := row.impulse
+		md := self.massData[i]
+
+		if row.motorMaxImpulse > 0 {
+			oldImpulseM := imp.impulseM
+			impulseM := oldImpulseM + md.motorMass*(-row.motorSpeed-self.relVels[i]-oldImpulseM*row.motorCfm)
+
+			// clamp motor impulse
+			maxImpulseM := row.motorMaxImpulse
+			if impulseM < -maxImpulseM {
+				impulseM = -maxImpulseM
+			} else if impulseM > maxImpulseM {
+				impulseM = maxImpulseM
+			}
+			imp.impulseM = impulseM
+
+			// compute delta motor impulse
+			dImpulseM := impulseM - oldImpulseM
+			self.dTotalImpulses[i] = dImpulseM
+
+			// update relative velocity
+			for j := range numRows {
+				self.relVels[j] += dImpulseM * invMassWithoutCfm[i][j]
+			}
+		}
+	}
+
+	// try all the boundaries
+	for i := range self.boundaryBuilder.numBoundaries {
+		// select a boundary
+		idx := self.velBoundarySelector.getIndex(i)
+		b := self.boundaryBuilder.boundaries[idx]
+
+		// try the case
+		if b.computeImpulses(self.info, self.massMatrix, self.relVels, self.impulses, self.dImpulses, 1, false) {
+			// found the solution
+			for j := range numRows {
+				dimp := self.dImpulses[j]
+				self.info.rows[j].impulse.impulse += dimp
+				self.dTotalImpulses[j] += dimp
+			}
+
+			// rank up the boundary
+			self.velBoundarySelector.selectBoundary(idx)
+			break
+		}
+	}
+
+	// apply delta impulses, only the motor impulses if no solution is found
+	for i := range numRows {
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, self.info.rows[i].jacobian, self.massData[i], self.dTotalImpulses[i])
+	}
+
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
+}
+
+func (self *DirectJointConstraintSolver) PostSolveVelocity(timeStep TimeStep) {
+	// compute total linear and angular impulse
+	var lin, ang Vec3
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		imp := row.impulse
+		j := row.jacobian
+		if j.IsLinearSet() {
+			lin = lin.AddRhsScaled(j.lin1, imp.impulse)
+		}
+		if j.IsAngularSet() {
+			ang = ang.AddRhsScaled(j.ang1, imp.impulse)
+		}
+	}
+
+	self.joint.appliedForce = lin.Scale(timeStep.InvDt)
+	self.joint.appliedTorque = ang.Scale(timeStep.InvDt)
+}
+
+func (self *DirectJointConstraintSolver) _updatePositionData() {
+	self.joint.impl.syncAnchors()
+	self.joint.impl.getPositionSolverInfo(self.info)
+
+	self.b1 = self.info.b1
+	self.b2 = self.info.b2
+
+	self.massMatrix.computeInvMass(self.info, self.massData)
+
+	// build boundaries
+	self.boundaryBuilder.buildBoundaries(self.info)
+
+	// update the size of the boundary selector
+	self.posBoundarySelector.setSize(self.boundaryBuilder.numBoundaries)
+}
+
+func (self *DirectJointConstraintSolver) PreSolvePosition(timeStep TimeStep) {
+	self._updatePositionData()
+
+	// clear position impulses
+	for i := range self.info.numRows {
+		self.info.rows[i].impulse.impulseP = 0
+	}
+}
+
+func (self *DirectJointConstraintSolver) SolvePositionSplitImpulse() {
+	numRows := self.info.numRows
+
+	lv1 := self.b1.pseudoVel
+	lv2 := self.b2.pseudoVel
+	av1 := self.b1.angPseudoVel
+	av2 := self.b2.angPseudoVel
+
+	for i := range numRows {
+		row := self.info.rows[i]
+
+		// compute relative velocity
+		self.relVels[i] = _measureJointRelVel(&lv1, &lv2, &av1, &av2, row.jacobian)
+
+		// get impulse
+		self.impulses[i] = row.impulse.impulseP
+	}
+
+	// try all the boundaries
+	for i := range self.boundaryBuilder.numBoundaries {
+		// select a boundary
+		idx := self.posBoundarySelector.getIndex(i)
+		b := self.boundaryBuilder.boundaries[idx]
+
+		// try the case
+		if b.computeImpulses(self.info, self.massMatrix, self.relVels, self.impulses, self.dImpulses, Settings.PositionSplitImpulseBaumgarte, false) {
+			// found the solution
+			for j := range numRows {
+				dimp := self.dImpulses[j]
+				self.info.rows[j].impulse.impulseP += dimp
+
+				// apply delta impulse
+				_applyJointImpulse(&lv1, &lv2, &av1, &av2, self.info.rows[j].jacobian, self.massData[j], dimp)
+			}
+
+			// rank up the boundary
+			self.posBoundarySelector.selectBoundary(idx)
+			break
+		}
+	}
+
+	self.b1.setSolvedPseudoVelocity(lv1, av1)
+	self.b2.setSolvedPseudoVelocity(lv2, av2)
+}
+
+func (self *DirectJointConstraintSolver) SolvePositionNgs(timeStep TimeStep) {
+	self._updatePositionData()
+
+	numRows := self.info.numRows
+	for i := range numRows {
+		// the estimated relative velocity is zero as no translation is applied yet
+		self.relVels[i] = 0
+		self.impulses[i] = self.info.rows[i].impulse.impulseP
+	}
+
+	var lv1, lv2, av1, av2 Vec3
+
+	// try all the boundaries
+	for i := range self.boundaryBuilder.numBoundaries {
+		// select a boundary
+		idx := self.posBoundarySelector.getIndex(i)
+		b := self.boundaryBuilder.boundaries[idx]
+
+		// try the case
+		if b.computeImpulses(self.info, self.massMatrix, self.relVels, self.impulses, self.dImpulses, Settings.PositionNgsBaumgarte, false) {
+			// found the solution
+			for j := range numRows {
+				dimp := self.dImpulses[j]
+				self.info.rows[j].impulse.impulseP += dimp
+
+				// apply delta impulse
+				_applyJointImpulse(&lv1, &lv2, &av1, &av2, self.info.rows[j].jacobian, self.massData[j], dimp)
+			}
+
+			// rank up the boundary
+			self.posBoundarySelector.selectBoundary(idx)
+			break
+		}
+	}
+
+	self.b1.applySolvedDisplacement(lv1, av1)
+	self.b2.applySolvedDisplacement(lv2, av2)
+}
+
+func (self *DirectJointConstraintSolver) PostSolve() {
+	self.joint.impl.syncAnchors()
+	self.joint.checkDestruction()
+}
@@ -0,0 +1,91 @@
+package demos
+
+//////////////////////////////////////////////// DistanceJoint
+// (goimo)
+// A distance joint keeps the distance between two anchor points within the lower and upper limits. The constraint is
+// one-sided, it only pushes or pulls the rigid bodies while the distance is out of the limits, so setting the lower
+// limit to `0` makes a rope with slack. This joint provides five degrees of freedom. You can enable a motor, and a
+// spring and damper effect of the constraint.
+
+type DistanceJoint struct {
+	*Joint
+
+	sd *SpringDamper
+	lm *TranslationalLimitMotor
+
+	distance float64
+
+	// the unit vector from the first anchor to the second one
+	direction Vec3
+}
+
+// Creates a new distance joint by configuration `config`.
+func NewDistanceJoint(config *DistanceJointConfig) *DistanceJoint {
+	j := &DistanceJoint{
+		Joint:     NewJoint(config.JointConfig, JointType_DISTANCE),
+		sd:        config.SpringDamper.Clone(),
+		lm:        config.LimitMotor.Clone(),
+		direction: Vec3{1, 0, 0},
+	}
+	j.impl = j
+	return j
+}
+
+func (self *DistanceJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	if self.sd.Frequency > 0 && isPositionPart {
+		return
+	}
+
+	motorMass := self.b1.invMass + self.b2.invMass
+	if motorMass != 0 {
+		motorMass = 1 / motorMass
+	}
+
+	row := info.AddRow(&self.impulses[0])
+	self.setSolverInfoRowLinear(row, self.distance, self.lm, motorMass, self.sd, timeStep, isPositionPart)
+	_setJacobianLinear(row.jacobian, self.direction, self.relativeAnchor1, self.relativeAnchor2)
+}
+
+func (self *DistanceJoint) computeErrors() {
+	diff := self.anchor2.Sub(self.anchor1)
+	self.distance = diff.Length()
+
+	// keep the last direction if the anchors coincide
+	if self.distance > 0 {
+		self.direction = diff.Scale(1 / self.distance)
+	}
+}
+
+// --- internal ---
+
+func (self *DistanceJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *DistanceJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *DistanceJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the spring and damper settings.
+func (self *DistanceJoint) GetSpringDamper() *SpringDamper {
+	return self.sd
+}
+
+// Returns the limits and motor settings.
+func (self *DistanceJoint) GetLimitMotor() *TranslationalLimitMotor {
+	return self.lm
+}
+
+// Returns the current distance between the anchor points.
+func (self *DistanceJoint) GetDistance() float64 {
+	return self.distance
+}
@@ -0,0 +1,33 @@
+package demos
+
+//////////////////////////////////////////////// DistanceJointConfig
+// (goimo)
+// A distance joint config is used for constructions of distance joints.
+
+type DistanceJointConfig struct {
+	*JointConfig
+
+	// The limits of the distance between the anchor points, and the motor along the line between them.
+	// Set the lower limit to `0` for ropes, or the same value as the upper limit for rigid rods.
+	LimitMotor *TranslationalLimitMotor
+
+	// The spring and damper along the line between the anchor points. The spring acts only while the distance is out
+	// of the limits.
+	SpringDamper *SpringDamper
+}
+
+func NewDistanceJointConfig() *DistanceJointConfig {
+	return &DistanceJointConfig{
+		JointConfig:  NewJointConfig(),
+		LimitMotor:   NewTranslationalLimitMotor().SetLimits(0, 1),
+		SpringDamper: NewSpringDamper(),
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchors `worldAnchor1` and `worldAnchor2`, and returns `this`.
+// The limits are not changed.
+func (self *DistanceJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor1, worldAnchor2 Vec3) *DistanceJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor1)
+	rigidBody2.GetLocalPointTo(worldAnchor2, &self.LocalAnchor2)
+	return self
+}
@@ -0,0 +1,31 @@
+package demos
+
+import (
+	"testing"
+)
+
+func TestDistanceJointLimits(t *testing.T) {
+	for _, solverType := range []ConstraintSolverType{ConstraintSolverType_ITERATIVE, ConstraintSolverType_DIRECT} {
+		w, base, box := jointTestWorld()
+		box.SetPosition(Vec3{1.5, 0, 0})
+		config := NewDistanceJointConfig().Init(base, box, Vec3{}, Vec3{1.5, 0, 0})
+		config.LimitMotor.SetLimits(1, 2)
+		config.SolverType = solverType
+		j := NewDistanceJoint(config)
+		w.AddJoint(j.Joint)
+
+		// the box flies away and the upper limit catches it
+		box.SetLinearVelocity(Vec3{3, 0, 0})
+		var maxDistance float64
+		for range 120 {
+			stepWorld(w, 1)
+			maxDistance = max(maxDistance, j.GetDistance())
+		}
+		if maxDistance > 2+3.0/60+Settings.LinearSlop {
+			t.Fatalf("solver %v: stretched to %v", solverType, maxDistance)
+		}
+		if d := j.GetDistance(); d < 1-Settings.LinearSlop*2 || d > 2+Settings.LinearSlop*2 {
+			t.Fatalf("solver %v: ended at %v", solverType, d)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package demos
+
+//////////////////////////////////////////////// GearJoint
+// (goimo)
+// A gear joint couples two revolute or prismatic joints, and keeps `coordinate1 + ratio * coordinate2` constant, where
+// the coordinates are the angles of revolute joints and the translations of prismatic joints. The joint acts on all
+// the rigid bodies of the coupled joints, so the frames the coupled joints move in may be dynamic too, like the chassis
+// of a vehicle. A gear joint is always solved by `GearJointConstraintSolver`, whatever `JointConfig.SolverType` is.
+
+type GearJoint struct {
+	*Joint
+
+	joint1 *Joint
+	joint2 *Joint
+	ratio  float64
+
+	// the accumulated coordinates of the coupled joints, angles are unwrapped
+	coordinate1 float64
+	coordinate2 float64
+
+	// the coordinates of the coupled joints at the last update, used to unwrap angles
+	rawCoordinate1 float64
+	rawCoordinate2 float64
+
+	// the value of `coordinate1 + ratio * coordinate2` to keep
+	constant float64
+
+	// the rigid bodies of the coupled joints the row acts on, coinciding ones merged, and the jacobian of each. the
+	// first one is always the first rigid body of the joint.
+	numBodies int
+	bodies    [4]*RigidBody
+	lins      [4]Vec3
+	angs      [4]Vec3
+}
+
+// Creates a new gear joint by configuration `config`.
+func NewGearJoint(config *GearJointConfig) *GearJoint {
+	_checkGearCoupledJoint(config.Joint1)
+	_checkGearCoupledJoint(config.Joint2)
+
+	j := &GearJoint{
+		Joint:  NewJoint(config.JointConfig, JointType_GEAR),
+		joint1: config.Joint1,
+		joint2: config.Joint2,
+		ratio:  config.Ratio,
+	}
+	j.impl = j
+	j.solver = NewGearJointConstraintSolver(j)
+
+	j.joint1.impl.syncAnchors()
+	j.joint2.impl.syncAnchors()
+	j.rawCoordinate1, _, _, _, _ = _getGearCoordinate(j.joint1)
+	j.rawCoordinate2, _, _, _, _ = _getGearCoordinate(j.joint2)
+	j.coordinate1 = j.rawCoordinate1
+	j.coordinate2 = j.rawCoordinate2
+	j.constant = j.coordinate1 + j.ratio*j.coordinate2
+
+	return j
+}
+
+// returns whether a gear joint can couple `joint`
+func _isGearCoupledJoint(joint *Joint) bool {
+	return joint != nil && (joint._type == JointType_REVOLUTE || joint._type == JointType_PRISMATIC)
+}
+
+func _checkGearCoupledJoint(joint *Joint) {
+	if !_isGearCoupledJoint(joint) {
+		panic("gear joint can only couple revolute joints and prismatic joints")
+	}
+}
+
+// returns the coordinate of a coupled joint, and the linear and angular jacobian of the coordinate with respect to the
+// joint's first and second rigid bodies
+func _getGearCoordinate(joint *Joint) (coordinate float64, lin1, ang1, lin2, ang2 Vec3) {
+	if j, ok := joint.impl.(*RevoluteJoint); ok {
+		return j.angle, Vec3{}, j.basisX1.Negate(), Vec3{}, j.basisX1
+	}
+	j := joint.impl.(*PrismaticJoint)
+	r1 := j.anchor2.Sub(j.b1.transform.position)
+	ang1 = j.basisX1.Cross(r1)
+	return j.translation, j.basisX1.Negate(), ang1, j.basisX1, j.relativeAnchor2.Cross(j.basisX1)
+}
+
+// updates the accumulated coordinate from the raw coordinate of a coupled joint
+func _accumulateGearCoordinate(joint *Joint, coordinate, rawCoordinate *float64, raw float64) {
+	delta := raw - *rawCoordinate
+	if joint._type == JointType_REVOLUTE {
+		// take the shortest way around the circle
+		delta = _wrapAngle(delta)
+	}
+	*coordinate += delta
+	*rawCoordinate = raw
+}
+
+func (self *GearJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	err := self.coordinate1 + self.ratio*self.coordinate2 - self.constant
+
+	// the jacobian is kept by the joint, as it spans more than two rigid bodies
+	row := info.AddRow(&self.impulses[0])
+	row.EqualLimit(err*erp, 0)
+	self.computeJacobian()
+}
+
+// adds the jacobian `lin` and `ang` of the rigid body `rb` to the row, merged if `rb` is already added
+func (self *GearJoint) addJacobian(rb *RigidBody, lin, ang Vec3) {
+	for i := range self.numBodies {
+		if self.bodies[i] == rb {
+			self.lins[i].AddEq(lin)
+			self.angs[i].AddEq(ang)
+			return
+		}
+	}
+	self.bodies[self.numBodies] = rb
+	self.lins[self.numBodies] = lin
+	self.angs[self.numBodies] = ang
+	self.numBodies++
+}
+
+// computes the jacobian of the row, the negated gradient of `coordinate1 + ratio * coordinate2`
+func (self *GearJoint) computeJacobian() {
+	self.numBodies = 0
+	_, lin1, ang1, lin2, ang2 := _getGearCoordinate(self.joint1)
+	self.addJacobian(self.joint1.b2, lin2.Negate(), ang2.Negate())
+	self.addJacobian(self.joint1.b1, lin1.Negate(), ang1.Negate())
+	_, lin1, ang1, lin2, ang2 = _getGearCoordinate(self.joint2)
+	self.addJacobian(self.joint2.b2, lin2.Scale(-self.ratio), ang2.Scale(-self.ratio))
+	self.addJacobian(self.joint2.b1, lin1.Scale(-self.ratio), ang1.Scale(-self.ratio))
+}
+
+func (self *GearJoint) computeErrors() {
+	self.joint1.impl.syncAnchors()
+	self.joint2.impl.syncAnchors()
+
+	raw1, _, _, _, _ := _getGearCoordinate(self.joint1)
+	raw2, _, _, _, _ := _getGearCoordinate(self.joint2)
+	_accumulateGearCoordinate(self.joint1, &self.coordinate1, &self.rawCoordinate1, raw1)
+	_accumulateGearCoordinate(self.joint2, &self.coordinate2, &self.rawCoordinate2, raw2)
+}
+
+// --- internal ---
+
+func (self *GearJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *GearJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *GearJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first coupled joint.
+func (self *GearJoint) GetJoint1() *Joint {
+	return self.joint1
+}
+
+// Returns the second coupled joint.
+func (self *GearJoint) GetJoint2() *Joint {
+	return self.joint2
+}
+
+// Returns the gear ratio.
+func (self *GearJoint) GetRatio() float64 {
+	return self.ratio
+}
+
+// Sets the gear ratio. The current coordinates of the coupled joints are kept.
+func (self *GearJoint) SetRatio(ratio float64) {
+	self.ratio = ratio
+	self.constant = self.coordinate1 + self.ratio*self.coordinate2
+}
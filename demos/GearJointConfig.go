@@ -0,0 +1,42 @@
+package demos
+
+//////////////////////////////////////////////// GearJointConfig
+// (goimo)
+// A gear joint config is used for constructions of gear joints.
+
+type GearJointConfig struct {
+	*JointConfig
+
+	// The first coupled joint, a revolute joint or a prismatic joint.
+	Joint1 *Joint
+
+	// The second coupled joint, a revolute joint or a prismatic joint.
+	Joint2 *Joint
+
+	// The gear ratio. The joint keeps `coordinate1 + ratio * coordinate2` constant, where the coordinates are the
+	// angles of revolute joints and the translations of prismatic joints.
+	Ratio float64
+}
+
+func NewGearJointConfig() *GearJointConfig {
+	return &GearJointConfig{
+		JointConfig: NewJointConfig(),
+		Ratio:       1,
+	}
+}
+
+// Sets the coupled joints `joint1` and `joint2`, the gear ratio `ratio`, the rigid bodies, and returns `this`.
+// The rigid bodies are the second rigid bodies of the coupled joints. The coupled joints must be revolute joints or
+// prismatic joints.
+func (self *GearJointConfig) Init(joint1, joint2 *Joint, ratio float64) *GearJointConfig {
+	_checkGearCoupledJoint(joint1)
+	_checkGearCoupledJoint(joint2)
+	self.Joint1 = joint1
+	self.Joint2 = joint2
+	self.Ratio = ratio
+	self.RigidBody1 = joint1.b2
+	self.RigidBody2 = joint2.b2
+	self.LocalAnchor1.Zero()
+	self.LocalAnchor2.Zero()
+	return self
+}
@@ -0,0 +1,194 @@
+package demos
+
+//////////////////////////////////////////////// GearJointConstraintSolver
+// (goimo)
+// A constraint solver of gear joints using projected Gauss-Seidel. The row of a gear joint acts on all the rigid bodies
+// of the joints it couples, which the joint solvers for two rigid bodies can't handle.
+
+type GearJointConstraintSolver struct {
+	*ConstraintSolver
+
+	joint *GearJoint
+	info  *JointSolverInfo
+
+	// impulse -> velocity change vectors of the rigid bodies of the row
+	invMLin [4]Vec3
+	invMAng [4]Vec3
+
+	mass float64
+}
+
+func NewGearJointConstraintSolver(joint *GearJoint) *GearJointConstraintSolver {
+	return &GearJointConstraintSolver{
+		ConstraintSolver: NewConstraintSolver(),
+
+		joint: joint,
+		info:  NewJointSolverInfo(),
+	}
+}
+
+// computes impulse -> velocity change vectors, and the mass of the row with `cfm`
+func (self *GearJointConstraintSolver) _computeMassData(cfm float64) {
+	j := self.joint
+	invMass := cfm
+	for i := range j.numBodies {
+		rb := j.bodies[i]
+		self.invMLin[i] = j.lins[i].Scale(rb.invMass)
+		self.invMAng[i] = j.angs[i].MulMat3(&rb.invInertia)
+		invMass += self.invMLin[i].Dot(j.lins[i]) + self.invMAng[i].Dot(j.angs[i])
+	}
+	self.mass = 0
+	if invMass != 0 {
+		self.mass = 1.0 / invMass
+	}
+}
+
+func (self *GearJointConstraintSolver) _updatePositionData() {
+	self.joint.syncAnchors()
+	self.joint.getPositionSolverInfo(self.info)
+	self._computeMassData(0)
+}
+
+// returns the clamped delta of the impulse `*impulse` by `dImpulse`
+func (self *GearJointConstraintSolver) _clampImpulse(impulse *float64, dImpulse float64) float64 {
+	row := self.info.rows[0]
+	oldImpulse := *impulse
+	*impulse = MathUtil.Clamp(oldImpulse+dImpulse, row.minImpulse, row.maxImpulse)
+	return *impulse - oldImpulse
+}
+
+// measures the relative velocity along the row
+func (self *GearJointConstraintSolver) _measureRelVel(vels, angVels *[4]Vec3) float64 {
+	j := self.joint
+	rv := 0.0
+	for i := range j.numBodies {
+		rv += vels[i].Dot(j.lins[i]) + angVels[i].Dot(j.angs[i])
+	}
+	return rv
+}
+
+// applies `impulse` along the row to the given velocities
+func (self *GearJointConstraintSolver) _applyImpulse(vels, angVels *[4]Vec3, impulse float64) {
+	for i := range self.joint.numBodies {
+		vels[i].AddScaledEq(self.invMLin[i], impulse)
+		angVels[i].AddScaledEq(self.invMAng[i], impulse)
+	}
+}
+
+func (self *GearJointConstraintSolver) _getVelocities(vels, angVels *[4]Vec3) {
+	j := self.joint
+	for i := range j.numBodies {
+		vels[i] = j.bodies[i].vel
+		angVels[i] = j.bodies[i].angVel
+	}
+}
+
+func (self *GearJointConstraintSolver) _setVelocities(vels, angVels *[4]Vec3) {
+	j := self.joint
+	for i := range j.numBodies {
+		j.bodies[i].setSolvedVelocity(vels[i], angVels[i])
+	}
+}
+
+func (self *GearJointConstraintSolver) PreSolveVelocity(timeStep TimeStep) { // override
+	self.joint.syncAnchors()
+	self.joint.getVelocitySolverInfo(timeStep, self.info)
+	self._computeMassData(self.info.rows[0].cfm)
+}
+
+func (self *GearJointConstraintSolver) WarmStart(timeStep TimeStep) { // override
+	imp := self.info.rows[0].impulse
+
+	var factor float64
+	if self.joint.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+		factor = Settings.JointWarmStartingFactorForBaungarte
+	} else {
+		factor = Settings.JointWarmStartingFactor
+	}
+
+	// adjust impulse for variable time step
+	factor *= timeStep.DtRatio
+
+	// warm starting disabled
+	if factor <= 0 {
+		imp.Clear()
+		return
+	}
+
+	imp.impulse *= factor
+
+	var vels, angVels [4]Vec3
+	self._getVelocities(&vels, &angVels)
+	self._applyImpulse(&vels, &angVels, imp.impulse)
+	self._setVelocities(&vels, &angVels)
+}
+
+func (self *GearJointConstraintSolver) SolveVelocity() { // override
+	row := self.info.rows[0]
+	imp := row.impulse
+
+	var vels, angVels [4]Vec3
+	self._getVelocities(&vels, &angVels)
+
+	rv := self._measureRelVel(&vels, &angVels)
+	impulse := self._clampImpulse(&imp.impulse, (row.rhs-rv-imp.impulse*row.cfm)*self.mass)
+	self._applyImpulse(&vels, &angVels, impulse)
+
+	self._setVelocities(&vels, &angVels)
+}
+
+func (self *GearJointConstraintSolver) PostSolveVelocity(timeStep TimeStep) { // override
+	// the force and torque applied to the first rigid body of the joint, the first one of the row
+	impulse := self.info.rows[0].impulse.impulse
+	self.joint.appliedForce = self.joint.lins[0].Scale(impulse * timeStep.InvDt)
+	self.joint.appliedTorque = self.joint.angs[0].Scale(impulse * timeStep.InvDt)
+}
+
+func (self *GearJointConstraintSolver) PreSolvePosition(timeStep TimeStep) { // override
+	self._updatePositionData()
+
+	// clear position impulses
+	self.info.rows[0].impulse.impulseP = 0
+}
+
+func (self *GearJointConstraintSolver) SolvePositionSplitImpulse() { // override
+	row := self.info.rows[0]
+	imp := row.impulse
+	j := self.joint
+
+	var vels, angVels [4]Vec3
+	for i := range j.numBodies {
+		vels[i] = j.bodies[i].pseudoVel
+		angVels[i] = j.bodies[i].angPseudoVel
+	}
+
+	rv := self._measureRelVel(&vels, &angVels)
+	impulseP := self._clampImpulse(&imp.impulseP, (row.rhs*Settings.PositionSplitImpulseBaumgarte-rv)*self.mass)
+	self._applyImpulse(&vels, &angVels, impulseP)
+
+	for i := range j.numBodies {
+		j.bodies[i].setSolvedPseudoVelocity(vels[i], angVels[i])
+	}
+}
+
+func (self *GearJointConstraintSolver) SolvePositionNgs(timeStep TimeStep) { // override
+	self._updatePositionData()
+
+	row := self.info.rows[0]
+	imp := row.impulse
+	j := self.joint
+
+	// estimate translation
+	var translations, rotations [4]Vec3
+	impulseP := self._clampImpulse(&imp.impulseP, row.rhs*Settings.PositionNgsBaumgarte*self.mass)
+	self._applyImpulse(&translations, &rotations, impulseP)
+
+	for i := range j.numBodies {
+		j.bodies[i].applySolvedDisplacement(translations[i], rotations[i])
+	}
+}
+
+func (self *GearJointConstraintSolver) PostSolve() { // override
+	self.joint.syncAnchors()
+	self.joint.checkDestruction()
+}
@@ -0,0 +1,92 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGearJointRemovedWithCoupledJoint(t *testing.T) {
+	w, base, box := jointTestWorld()
+	hinge1 := NewRevoluteJoint(NewRevoluteJointConfig().Init(base, box, Vec3{}, Vec3{0, 0, 1}))
+	hinge2 := NewRevoluteJoint(NewRevoluteJointConfig().Init(base, box, Vec3{}, Vec3{0, 0, 1}))
+	gear := NewGearJoint(NewGearJointConfig().Init(hinge1.Joint, hinge2.Joint, 1))
+	w.AddJoint(hinge1.Joint)
+	w.AddJoint(hinge2.Joint)
+	w.AddJoint(gear.Joint)
+
+	w.RemoveJoint(hinge1.Joint)
+	if gear.GetWorld() != nil || hinge2.GetWorld() != w || w.GetNumJoints() != 1 {
+		t.Fatalf("%v joints left in the world", w.GetNumJoints())
+	}
+}
+
+// returns the angular momentum of the rigid bodies of `w` about the origin
+func angularMomentum(w *World) Vec3 {
+	var l Vec3
+	for rb := w.GetRigidBodyList(); rb != nil; rb = rb.GetNext() {
+		if rb.GetType() != RigidBodyType_DYNAMIC {
+			continue
+		}
+		var inertia Mat3
+		MathUtil.Mat3_inv(&inertia, &rb.invInertia)
+		spin := rb.angVel.MulMat3(&inertia)
+		orbit := rb.transform.position.Cross(rb.vel)
+		l.AddEq(spin)
+		l.AddScaledEq(orbit, rb.mass)
+	}
+	return l
+}
+
+func TestGearJointDynamicChassis(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, &Vec3{})
+	box := func(position, halfExtents Vec3) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = position
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(halfExtents)
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+	chassis := box(Vec3{}, Vec3{2, 0.25, 0.5})
+	wheel1 := box(Vec3{-1.5, 0, 1}, Vec3{0.3, 0.3, 0.3})
+	wheel2 := box(Vec3{1.5, 0, 1}, Vec3{0.3, 0.3, 0.3})
+
+	jc := NewRevoluteJointConfig().Init(chassis, wheel1, Vec3{-1.5, 0, 1}, Vec3{0, 0, 1})
+	jc.LimitMotor.SetMotor(3, 10)
+	hinge1 := NewRevoluteJoint(jc)
+	hinge2 := NewRevoluteJoint(NewRevoluteJointConfig().Init(chassis, wheel2, Vec3{1.5, 0, 1}, Vec3{0, 0, 1}))
+	gear := NewGearJoint(NewGearJointConfig().Init(hinge1.Joint, hinge2.Joint, 2))
+	w.AddJoint(hinge1.Joint)
+	w.AddJoint(hinge2.Joint)
+	w.AddJoint(gear.Joint)
+
+	// the gear couples the angles relative to the spinning chassis, and only moves momentum between the bodies
+	chassis.SetAngularVelocity(Vec3{0, 0, 1})
+	l0 := angularMomentum(w)
+	stepWorld(w, 120)
+	if err := gear.coordinate1 + 2*gear.coordinate2 - gear.constant; math.Abs(err) > Settings.AngularSlop {
+		t.Fatalf("the gear slipped by %v", err)
+	}
+	if gear.coordinate1 < 1 {
+		t.Fatalf("the motor turned the first wheel by %v", gear.coordinate1)
+	}
+	l := angularMomentum(w)
+	if dl := l.Sub(l0); dl.Length() > 1e-4*l0.Length() {
+		t.Fatalf("the angular momentum changed from %v to %v", l0, l)
+	}
+}
+
+func TestGearJointCoupledTypes(t *testing.T) {
+	w, base, box := jointTestWorld()
+	hinge := NewRevoluteJoint(NewRevoluteJointConfig().Init(base, box, Vec3{}, Vec3{0, 0, 1}))
+	distance := NewDistanceJoint(NewDistanceJointConfig().Init(base, box, Vec3{}, Vec3{}))
+	w.AddJoint(hinge.Joint)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("a gear joint coupled a distance joint")
+		}
+	}()
+	NewGearJointConfig().Init(hinge.Joint, distance.Joint, 1)
+}
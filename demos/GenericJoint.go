@@ -0,0 +1,165 @@
+package demos
+
+//////////////////////////////////////////////// GenericJoint
+// (oimo/dynamics/constraint/joint/GenericJoint.go)
+// A generic joint (a.k.a. 6-DoF joint) constrains two rigid bodies in highly flexible way, so that every translational
+// and rotational axis can be locked, unlocked, springy, or powered by a motor like other joints. Note that the
+// rotation angle along the second (Y) axis must be within [-PI/2, PI/2]. This limitation is created to avoid
+// singularities of the x-y-z Euler angles, so set a limit for the Y axis, or it will get unstable around the
+// singularity.
+
+type GenericJoint struct {
+	*Joint
+
+	translSds [3]*SpringDamper
+	rotSds    [3]*SpringDamper
+	translLms [3]*TranslationalLimitMotor
+	rotLms    [3]*RotationalLimitMotor
+
+	translations Vec3
+	angles       Vec3
+
+	// jacobian axes of the euler angles
+	axisX Vec3
+	axisY Vec3
+	axisZ Vec3
+}
+
+// Creates a new generic joint by configuration `config`.
+func NewGenericJoint(config *GenericJointConfig) *GenericJoint {
+	j := &GenericJoint{
+		Joint: NewJoint(config.JointConfig, JointType_GENERIC),
+	}
+	j.impl = j
+
+	for i := range 3 {
+		j.translSds[i] = config.TranslationalSpringDampers[i].Clone()
+		j.rotSds[i] = config.RotationalSpringDampers[i].Clone()
+		j.translLms[i] = config.TranslationalLimitMotors[i].Clone()
+		j.rotLms[i] = config.RotationalLimitMotors[i].Clone()
+	}
+
+	j.localBasisX1 = config.LocalBasis1.GetCol(0)
+	j.localBasisY1 = config.LocalBasis1.GetCol(1)
+	j.localBasisX2 = config.LocalBasis2.GetCol(0)
+	j.localBasisY2 = config.LocalBasis2.GetCol(1)
+	j.buildLocalBasesFromXY()
+
+	return j
+}
+
+// returns whether the row of the axis does nothing and can be skipped
+func _isFreeAxis(lower, upper, motorForce float64) bool {
+	return lower > upper && motorForce <= 0
+}
+
+func (self *GenericJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	translations := [3]float64{self.translations.x, self.translations.y, self.translations.z}
+	translAxes := [3]Vec3{self.basisX1, self.basisY1, self.basisZ1}
+	angles := [3]float64{self.angles.x, self.angles.y, self.angles.z}
+	rotAxes := [3]Vec3{self.axisX, self.axisY, self.axisZ}
+
+	translMass := self.b1.invMass + self.b2.invMass
+	if translMass != 0 {
+		translMass = 1 / translMass
+	}
+
+	// linear
+	for i := range 3 {
+		lm := self.translLms[i]
+		sd := self.translSds[i]
+		if _isFreeAxis(lm.LowerLimit, lm.UpperLimit, lm.MotorForce) || (sd.Frequency > 0 && isPositionPart) {
+			continue
+		}
+		row := info.AddRow(&self.impulses[i])
+		self.setSolverInfoRowLinear(row, translations[i], lm, translMass, sd, timeStep, isPositionPart)
+		self.setJacobianLinear(row.jacobian, translAxes[i])
+	}
+
+	// angular
+	for i := range 3 {
+		lm := self.rotLms[i]
+		sd := self.rotSds[i]
+		if _isFreeAxis(lm.LowerLimit, lm.UpperLimit, lm.MotorTorque) || (sd.Frequency > 0 && isPositionPart) {
+			continue
+		}
+		row := info.AddRow(&self.impulses[3+i])
+		self.setSolverInfoRowAngular(row, angles[i], lm, self.computeEffectiveInertiaMoment(rotAxes[i]), sd, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, rotAxes[i])
+	}
+}
+
+func (self *GenericJoint) computeErrors() {
+	anchorDiff := self.anchor2.Sub(self.anchor1)
+	self.translations.x = anchorDiff.Dot(self.basisX1)
+	self.translations.y = anchorDiff.Dot(self.basisY1)
+	self.translations.z = anchorDiff.Dot(self.basisZ1)
+
+	self.angles, self.axisX, self.axisY, self.axisZ = self.computeEulerAngles()
+}
+
+// --- internal ---
+
+func (self *GenericJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *GenericJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *GenericJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first (x) rotation axis of the relative Euler angles.
+func (self *GenericJoint) GetAxisX() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second (y) rotation axis of the relative Euler angles.
+func (self *GenericJoint) GetAxisY() Vec3 {
+	y := self.basisZ2.Cross(self.basisX1)
+	y.Normalize()
+	return y
+}
+
+// Returns the third (z) rotation axis of the relative Euler angles.
+func (self *GenericJoint) GetAxisZ() Vec3 {
+	return self.basisZ2
+}
+
+// Returns the translational spring and damper settings along the first body's constraint basis.
+func (self *GenericJoint) GetTranslationalSpringDampers() [3]*SpringDamper {
+	return self.translSds
+}
+
+// Returns the rotational spring and damper settings along the rotation axes of the relative x-y-z Euler angles.
+func (self *GenericJoint) GetRotationalSpringDampers() [3]*SpringDamper {
+	return self.rotSds
+}
+
+// Returns the translational limits and motor settings along the first body's constraint basis.
+func (self *GenericJoint) GetTranslationalLimitMotors() [3]*TranslationalLimitMotor {
+	return self.translLms
+}
+
+// Returns the rotational limits and motor settings along the rotation axes of the relative x-y-z Euler angles.
+func (self *GenericJoint) GetRotationalLimitMotors() [3]*RotationalLimitMotor {
+	return self.rotLms
+}
+
+// Returns the relative x-y-z Euler angles.
+func (self *GenericJoint) GetAngles() Vec3 {
+	return self.angles
+}
+
+// Returns the translations along the first rigid body's constraint basis.
+func (self *GenericJoint) GetTranslations() Vec3 {
+	return self.translations
+}
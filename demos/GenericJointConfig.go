@@ -0,0 +1,62 @@
+package demos
+
+//////////////////////////////////////////////// GenericJointConfig
+// (oimo/dynamics/constraint/joint/GenericJointConfig.go)
+// A generic joint config is used for constructions of generic joints.
+
+type GenericJointConfig struct {
+	*JointConfig
+
+	// The first body's local constraint basis.
+	LocalBasis1 Mat3
+
+	// The second body's local constraint basis.
+	LocalBasis2 Mat3
+
+	// The translational limits and motors along the first body's constraint basis.
+	TranslationalLimitMotors [3]*TranslationalLimitMotor
+
+	// The rotational limits and motors along the rotation axes of the relative x-y-z Euler angles.
+	RotationalLimitMotors [3]*RotationalLimitMotor
+
+	// The translational springs and dampers along the first body's constraint basis.
+	TranslationalSpringDampers [3]*SpringDamper
+
+	// The rotational springs and dampers along the rotation axes of the relative x-y-z Euler angles.
+	RotationalSpringDampers [3]*SpringDamper
+}
+
+// Creates a new generic joint config. All the axes are locked by default.
+func NewGenericJointConfig() *GenericJointConfig {
+	c := &GenericJointConfig{
+		JointConfig: NewJointConfig(),
+	}
+	c.LocalBasis1.Identity()
+	c.LocalBasis2.Identity()
+	for i := range 3 {
+		c.TranslationalLimitMotors[i] = NewTranslationalLimitMotor().SetLimits(0, 0)
+		c.RotationalLimitMotors[i] = NewRotationalLimitMotor().SetLimits(0, 0)
+		c.TranslationalSpringDampers[i] = NewSpringDamper()
+		c.RotationalSpringDampers[i] = NewSpringDamper()
+	}
+	return c
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local bases from the world bases `worldBasis1`
+// and `worldBasis2`, and returns `this`.
+func (self *GenericJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor Vec3, worldBasis1, worldBasis2 *Mat3) *GenericJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+
+	var x, y, z Vec3
+	rigidBody1.GetLocalVectorTo(worldBasis1.GetCol(0), &x)
+	rigidBody1.GetLocalVectorTo(worldBasis1.GetCol(1), &y)
+	rigidBody1.GetLocalVectorTo(worldBasis1.GetCol(2), &z)
+	MathUtil.Mat3_fromCols(&self.LocalBasis1, &x, &y, &z)
+
+	rigidBody2.GetLocalVectorTo(worldBasis2.GetCol(0), &x)
+	rigidBody2.GetLocalVectorTo(worldBasis2.GetCol(1), &y)
+	rigidBody2.GetLocalVectorTo(worldBasis2.GetCol(2), &z)
+	MathUtil.Mat3_fromCols(&self.LocalBasis2, &x, &y, &z)
+
+	return self
+}
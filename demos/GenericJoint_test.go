@@ -0,0 +1,36 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenericJointAxes(t *testing.T) {
+	w, base, box := jointTestWorld()
+	var basis Mat3
+	basis.Identity()
+	config := NewGenericJointConfig().Init(base, box, Vec3{}, &basis, &basis)
+	config.TranslationalLimitMotors[0].SetLimits(0, 0.4).SetMotor(1, 100)
+	config.RotationalLimitMotors[2].SetLimits(1, 0)
+	j := NewGenericJoint(config)
+	w.AddJoint(j.Joint)
+
+	// push along and about every axis; only the x translation and the z rotation may follow
+	box.SetLinearVelocity(Vec3{0, 1, 1})
+	box.SetAngularVelocity(Vec3{1, 1, 1})
+	stepWorld(w, 60)
+	translations := j.GetTranslations()
+	angles := j.GetAngles()
+	if math.Abs(translations.x-0.4) > Settings.LinearSlop*2 {
+		t.Fatalf("the x translation stopped at %v", translations.x)
+	}
+	if math.Abs(translations.y) > Settings.LinearSlop*2 || math.Abs(translations.z) > Settings.LinearSlop*2 {
+		t.Fatalf("moved along locked axes: %v", translations)
+	}
+	if math.Abs(angles.x) > Settings.AngularSlop*2 || math.Abs(angles.y) > Settings.AngularSlop*2 {
+		t.Fatalf("rotated about locked axes: %v", angles)
+	}
+	if angles.z < 0.5 {
+		t.Fatalf("didn't rotate about the free axis: %v", angles.z)
+	}
+}
@@ -17,6 +17,9 @@ type Geometry struct { // implements IGeometry
 	_type        GeometryType
 	volume       float64
 	inertiaCoeff Mat3 // I / mass
+
+	// the concrete geometry (`*BoxGeometry` etc...), overridden methods are called through this
+	impl IGeometry
 }
 
 func NewGeometry(_type_ GeometryType) *Geometry {
@@ -24,6 +27,7 @@ func NewGeometry(_type_ GeometryType) *Geometry {
 		_type: _type_,
 	}
 	g.inertiaCoeff.Identity()
+	g.impl = g
 	return g
 }
 
@@ -52,7 +56,7 @@ func (geo *Geometry) RayCast(begin, end Vec3, transform *Transform, hit *RayCast
 	MathUtil.Vec3_mulMat3Transposed(&beginLocal, &beginLocal, &transform.rotation)
 	MathUtil.Vec3_mulMat3Transposed(&endLocal, &endLocal, &transform.rotation)
 
-	if geo.RayCastLocal(beginLocal, endLocal, hit) {
+	if geo.impl.RayCastLocal(beginLocal, endLocal, hit) {
 		// local -> global
 		MathUtil.Vec3_mulMat3(&hit.Position, &hit.Position, &transform.rotation)
 		MathUtil.Vec3_mulMat3(&hit.Normal, &hit.Normal, &transform.rotation)
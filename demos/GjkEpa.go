@@ -6,7 +6,9 @@ import "github.com/Salwan/goimo/debug"
 // (oimo/collision/narrowphase/detector/gjkepa/GjkEpa.go)
 // GJK algorithm and EPA for narrow-phase collision detection.
 
-var GjkEpaInstance *GjkEpa = _newGjkEpa()
+// The shared instance used by ray casts against convex geometries. A `GjkEpa` keeps its working data in itself, so
+// code that may run concurrently creates its own instance with `NewGjkEpa`.
+var GjkEpaInstance *GjkEpa = NewGjkEpa()
 
 type GjkEpa struct {
 	c1  IConvexGeometry
@@ -49,8 +51,7 @@ type GjkEpa struct {
 	Distance      float64 // Computed distance between two geometries. This value may be negative if two geometries are overlapping.
 }
 
-// Should not be called directly as GjkEpa is a singleton, access with: GjkEpaInstance
-func _newGjkEpa() *GjkEpa {
+func NewGjkEpa() *GjkEpa {
 	g := &GjkEpa{
 		s:  make([]Vec3, 4),
 		w1: make([]Vec3, 4),
@@ -6,11 +6,15 @@ package demos
 
 type GjkEpaDetector struct {
 	*Detector
+
+	// the detector is shared by the contacts of a collision matrix, and detects with its own instance
+	gjkEpa *GjkEpa
 }
 
 func NewGjkEpaDetector() *GjkEpaDetector {
 	return &GjkEpaDetector{
 		Detector: NewDetector(false),
+		gjkEpa:   NewGjkEpa(),
 	}
 }
 
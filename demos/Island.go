@@ -50,6 +50,14 @@ func (island *Island) SetGravity(gravity *Vec3) {
 
 // steps the single rigid body
 func (island *Island) StepSingleRigidBody(timeStep TimeStep, rb *RigidBody) {
+	island.stepSingleRigidBody(timeStep, rb)
+	if !rb.sleeping {
+		rb.syncShapes()
+	}
+}
+
+// steps the single rigid body without synchronizing its shapes with the broad-phase
+func (island *Island) stepSingleRigidBody(timeStep TimeStep, rb *RigidBody) {
 	dt := timeStep.Dt
 
 	// store previous transform
@@ -90,7 +98,6 @@ func (island *Island) StepSingleRigidBody(timeStep TimeStep, rb *RigidBody) {
 			MathUtil.Vec3_scale(&rb.angVel, &rb.angVel, angScale)
 		}
 		rb.integrate(dt)
-		rb.syncShapes()
 	}
 }
 
@@ -135,13 +142,56 @@ func (island *Island) AddConstraintSolver(solver IConstraintSolver, positionCorr
 	}
 }
 
-// steps the island with multiple bodies and constraints
-func (island *Island) Step(timeStep TimeStep, numVelocityIterations int, numPositionIterations int) {
+// applies gravity, forces and damping to the velocities of the dynamic rigid body
+func (island *Island) _applyForces(rb *RigidBody, dt float64) {
+	// damping
+	linScale := fastInvExp(dt * rb.linearDamping)
+	angScale := fastInvExp(dt * rb.angularDamping)
+
+	// compute accelerations
+	linAcc := island.gravity.Scale(rb.gravityScale)
+	MathUtil.Vec3_addRhsScaled(&linAcc, &linAcc, &rb.force, rb.invMass)
+	var angAcc Vec3
+	MathUtil.Vec3_mulMat3(&angAcc, &rb.torque, &rb.invInertia)
+
+	// update velocity
+	MathUtil.Vec3_addRhsScaled(&rb.vel, &rb.vel, &linAcc, dt)
+	MathUtil.Vec3_scale(&rb.vel, &rb.vel, linScale)
+	MathUtil.Vec3_addRhsScaled(&rb.angVel, &rb.angVel, &angAcc, dt)
+	MathUtil.Vec3_scale(&rb.angVel, &rb.angVel, angScale)
+}
+
+// steps the island with multiple bodies and constraints. If `numSubSteps` is greater than one, the velocities are
+// solved and integrated in that many sub-steps, sharing the velocity iterations, while the contacts detected at the
+// start of the step are relaxed by the positions between sub-steps.
+func (island *Island) Step(timeStep TimeStep, numVelocityIterations int, numPositionIterations int, numSubSteps int) {
+	if island.solve(timeStep, numVelocityIterations, numPositionIterations, numSubSteps) {
+		island.finish()
+	}
+}
+
+// solves and integrates the rigid bodies of the island, touching nothing outside of it so that islands can be solved
+// concurrently. Returns false if the island has fallen asleep instead.
+func (island *Island) solve(timeStep TimeStep, numVelocityIterations int, numPositionIterations int, numSubSteps int) bool {
 	dt := timeStep.Dt
 
+	// articulations integrate their rigid bodies once per step
+	for i := range island.numRigidBodies {
+		if island.rigidBodies[i].articulationLink != nil {
+			numSubSteps = 1
+			break
+		}
+	}
+	numSubSteps = max(numSubSteps, 1)
+
+	subStep := timeStep
+	subStep.Dt = dt / float64(numSubSteps)
+	subStep.InvDt = 1.0 / subStep.Dt
+	numSubStepIterations := (numVelocityIterations + numSubSteps - 1) / numSubSteps
+
 	sleepIsland := true
 
-	// sleep check and apply gravity
+	// sleep check
 	for i := range island.numRigidBodies {
 		rb := island.rigidBodies[i]
 
@@ -167,25 +217,6 @@ func (island *Island) Step(timeStep TimeStep, numVelocityIterations int, numPosi
 			// awaken the whole island
 			sleepIsland = false
 		}
-
-		// apply forces
-		if rb._type == RigidBodyType_DYNAMIC {
-			// damping
-			linScale := fastInvExp(dt * rb.linearDamping)
-			angScale := fastInvExp(dt * rb.angularDamping)
-
-			// compute accelerations
-			linAcc := island.gravity.Scale(rb.gravityScale)
-			MathUtil.Vec3_addRhsScaled(&linAcc, &linAcc, &rb.force, rb.invMass)
-			var angAcc Vec3
-			MathUtil.Vec3_mulMat3(&angAcc, &rb.torque, &rb.invInertia)
-
-			// update velocity
-			MathUtil.Vec3_addRhsScaled(&rb.vel, &rb.vel, &linAcc, dt)
-			MathUtil.Vec3_scale(&rb.vel, &rb.vel, linScale)
-			MathUtil.Vec3_addRhsScaled(&rb.angVel, &rb.angVel, &angAcc, dt)
-			MathUtil.Vec3_scale(&rb.angVel, &rb.angVel, angScale)
-		}
 	}
 
 	if sleepIsland {
@@ -193,33 +224,52 @@ func (island *Island) Step(timeStep TimeStep, numVelocityIterations int, numPosi
 		for i := range island.numRigidBodies {
 			island.rigidBodies[i].Sleep()
 		}
-		return
+		return false
 	}
 
 	// ----------------- test (Oimo) --------------------
 	// omitted as its commented out
 
-	// solve velocity
-	for i := range island.numSolvers {
-		island.solvers[i].PreSolveVelocity(timeStep)
-	}
-	for i := range island.numSolvers {
-		island.solvers[i].WarmStart(timeStep)
-	}
-	for range numVelocityIterations {
+	for s := range numSubSteps {
+		if s > 0 {
+			// warm start from the last sub-step
+			subStep.DtRatio = 1
+			subStep.subStep = s
+		}
+
+		// apply forces, articulations have already done it in joint space
+		for i := range island.numRigidBodies {
+			rb := island.rigidBodies[i]
+			if rb._type == RigidBodyType_DYNAMIC && rb.articulationLink == nil {
+				island._applyForces(rb, subStep.Dt)
+			}
+		}
+
+		// solve velocity
 		for i := range island.numSolvers {
-			island.solvers[i].SolveVelocity()
+			island.solvers[i].PreSolveVelocity(subStep)
+		}
+		for i := range island.numSolvers {
+			island.solvers[i].WarmStart(subStep)
+		}
+		for range numSubStepIterations {
+			for i := range island.numSolvers {
+				island.solvers[i].SolveVelocity()
+			}
 		}
-	}
 
-	// post-solve (velocity)
-	for i := range island.numSolvers {
-		island.solvers[i].PostSolveVelocity(timeStep)
-	}
+		// post-solve (velocity)
+		for i := range island.numSolvers {
+			island.solvers[i].PostSolveVelocity(subStep)
+		}
 
-	// integrate
-	for i := range island.numRigidBodies {
-		island.rigidBodies[i].integrate(dt)
+		// integrate, articulations integrate their rigid bodies in joint space
+		for i := range island.numRigidBodies {
+			rb := island.rigidBodies[i]
+			if rb.articulationLink == nil {
+				rb.integrate(subStep.Dt)
+			}
+		}
 	}
 
 	// solve split impulse
@@ -246,7 +296,11 @@ func (island *Island) Step(timeStep TimeStep, numVelocityIterations int, numPosi
 			island.solversNgs[i].SolvePositionNgs(timeStep)
 		}
 	}
+	return true
+}
 
+// runs the post-solve of the constraints and synchronizes the shapes, this may touch the world and the broad-phase
+func (island *Island) finish() {
 	// post-solve (some constraints may be removed)
 	for i := range island.numSolvers {
 		island.solvers[i].PostSolve()
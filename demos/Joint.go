@@ -1,10 +1,22 @@
 package demos
 
+import (
+	"fmt"
+	"math"
+)
+
 // //////////////////////// Joint
 // (oimo/dynamics/constraint/joint/Joint.go)
 // The base class of joints. Joints are used to connect two rigid bodies in various ways. See `JointType` for all types of joints.
 
-type Joint struct {
+// Methods of `Joint` that are overridden by the concrete joint types.
+type IJoint interface {
+	syncAnchors()
+	getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo)
+	getPositionSolverInfo(info *JointSolverInfo)
+}
+
+type Joint struct { // implements IJoint
 	b1 *RigidBody
 	b2 *RigidBody
 
@@ -45,35 +57,41 @@ type Joint struct {
 	appliedForce  Vec3
 	appliedTorque Vec3
 
-	breakForce  float64
-	breakTorque float64
+	breakForce    float64
+	breakTorque   float64
+	breakCallback IJointBreakCallback
 
-	_type int
+	_type JointType
 
 	solver IConstraintSolver
 
+	// the concrete joint (`*PrismaticJoint` etc...), overridden methods are called through this
+	impl IJoint
+
 	// Extra field that users can use for their own purposes.
 	userData any
 }
 
-func NewJoint(config *JointConfig, _type int) *Joint {
+func NewJoint(config *JointConfig, _type JointType) *Joint {
 	j := &Joint{
-		positionCorrectionAlgorithm: Settings.DefaultJointPositionCorrectionAlgorithm,
+		positionCorrectionAlgorithm: config.PositionCorrectionAlgorithm,
 		_type:                       _type,
-		b1:                          config.rigidBody1,
-		b2:                          config.rigidBody2,
-		allowCollision:              config.allowCollision,
-		breakForce:                  config.breakForce,
-		breakTorque:                 config.breakTorque,
-		localAnchor1:                config.localAnchor1,
-		localAnchor2:                config.localAnchor2,
+		b1:                          config.RigidBody1,
+		b2:                          config.RigidBody2,
+		allowCollision:              config.AllowCollision,
+		breakForce:                  config.BreakForce,
+		breakTorque:                 config.BreakTorque,
+		breakCallback:               config.BreakCallback,
+		localAnchor1:                config.LocalAnchor1,
+		localAnchor2:                config.LocalAnchor2,
 		impulses:                    make([]JointImpulse, Settings.MaxJacobianRows),
 	}
+	j.impl = j
 
 	j.link1 = NewJointLink(j)
 	j.link2 = NewJointLink(j)
 
-	switch config.solverType {
+	switch config.SolverType {
 	case ConstraintSolverType_DIRECT:
 		j.solver = NewDirectJointConstraintSolver(j)
 	case ConstraintSolverType_ITERATIVE:
@@ -101,22 +119,678 @@ func (c *Joint) SetPrev(x *Joint) {
 	c.prev = x
 }
 
+// --- private ---
+
+// normalizes `v`, or sets it to `def` if it's zero
+func _validateBasis(v *Vec3, def Vec3) {
+	if v.Dot(*v) == 0 {
+		*v = def
+	} else {
+		v.Normalize()
+	}
+}
+
+func (self *Joint) buildLocalBasesFromX() {
+	// validate X
+	_validateBasis(&self.localBasisX1, Vec3{1, 0, 0})
+	_validateBasis(&self.localBasisX2, Vec3{1, 0, 0})
+
+	var slerpQ Quat
+	var slerpM Mat3
+	MathUtil.Quat_arc(&slerpQ, &self.localBasisX1, &self.localBasisX2)
+	MathUtil.Mat3_fromQuat(&slerpM, &slerpQ)
+
+	MathUtil.Vec3_perp(&self.localBasisY1, &self.localBasisX1)
+	self.localBasisZ1 = self.localBasisX1.Cross(self.localBasisY1)
+
+	MathUtil.Vec3_mulMat3(&self.localBasisY2, &self.localBasisY1, &slerpM)
+	MathUtil.Vec3_mulMat3(&self.localBasisZ2, &self.localBasisZ1, &slerpM)
+}
+
+func (self *Joint) buildLocalBasesFromXY() {
+	// validate X
+	_validateBasis(&self.localBasisX1, Vec3{1, 0, 0})
+	_validateBasis(&self.localBasisX2, Vec3{1, 0, 0})
+
+	// build Z and recompute Y
+	self.localBasisZ1 = self.localBasisX1.Cross(self.localBasisY1)
+	self.localBasisZ2 = self.localBasisX2.Cross(self.localBasisY2)
+
+	if self.localBasisZ1.Dot(self.localBasisZ1) == 0 {
+		// invalid Y, set Y perpendicular to X
+		MathUtil.Vec3_perp(&self.localBasisY1, &self.localBasisX1)
+		self.localBasisZ1 = self.localBasisX1.Cross(self.localBasisY1)
+	} else {
+		// normalize Z and recompute Y
+		self.localBasisZ1.Normalize()
+		self.localBasisY1 = self.localBasisZ1.Cross(self.localBasisX1)
+	}
+
+	if self.localBasisZ2.Dot(self.localBasisZ2) == 0 {
+		// invalid Y, set Y perpendicular to X
+		MathUtil.Vec3_perp(&self.localBasisY2, &self.localBasisX2)
+		self.localBasisZ2 = self.localBasisX2.Cross(self.localBasisY2)
+	} else {
+		// normalize Z and recompute Y
+		self.localBasisZ2.Normalize()
+		self.localBasisY2 = self.localBasisZ2.Cross(self.localBasisX2)
+	}
+}
+
+func (self *Joint) buildLocalBasesFromX1Z2() {
+	// validate X1 and Z2
+	_validateBasis(&self.localBasisX1, Vec3{1, 0, 0})
+	_validateBasis(&self.localBasisZ2, Vec3{0, 0, 1})
+
+	tf1 := &self.b1.transform
+	tf2 := &self.b2.transform
+
+	// compute world bases
+	worldX1 := self.localBasisX1.MulMat3(&tf1.rotation)
+	worldZ2 := self.localBasisZ2.MulMat3(&tf2.rotation)
+	worldY := worldZ2.Cross(worldX1)
+	if worldY.Dot(worldY) == 0 {
+		MathUtil.Vec3_perp(&worldY, &worldX1)
+	} else {
+		worldY.Normalize()
+	}
+	worldZ1 := worldX1.Cross(worldY)
+	worldX2 := worldY.Cross(worldZ2)
+
+	// return to local
+	self.localBasisX1 = worldX1.MulMat3Transposed(&tf1.rotation)
+	self.localBasisY1 = worldY.MulMat3Transposed(&tf1.rotation)
+	self.localBasisZ1 = worldZ1.MulMat3Transposed(&tf1.rotation)
+	self.localBasisX2 = worldX2.MulMat3Transposed(&tf2.rotation)
+	self.localBasisY2 = worldY.MulMat3Transposed(&tf2.rotation)
+	self.localBasisZ2 = worldZ2.MulMat3Transposed(&tf2.rotation)
+}
+
+func (self *Joint) buildLocalBasesFromXY1X2() {
+	// validate X1
+	_validateBasis(&self.localBasisX1, Vec3{1, 0, 0})
+
+	// build Z1 and recompute Y1
+	self.localBasisZ1 = self.localBasisX1.Cross(self.localBasisY1)
+	if self.localBasisZ1.Dot(self.localBasisZ1) == 0 {
+		// invalid Y1, set Y1 perpendicular to X1
+		MathUtil.Vec3_perp(&self.localBasisY1, &self.localBasisX1)
+		self.localBasisZ1 = self.localBasisX1.Cross(self.localBasisY1)
+	} else {
+		// normalize Z1 and recompute Y1
+		self.localBasisZ1.Normalize()
+		self.localBasisY1 = self.localBasisZ1.Cross(self.localBasisX1)
+	}
+
+	// compute Y2 and Z2 by the shortest arc rotation from X1 to X2
+	_validateBasis(&self.localBasisX2, Vec3{1, 0, 0})
+
+	var slerpQ Quat
+	var slerpM Mat3
+	MathUtil.Quat_arc(&slerpQ, &self.localBasisX1, &self.localBasisX2)
+	MathUtil.Mat3_fromQuat(&slerpM, &slerpQ)
+
+	MathUtil.Vec3_mulMat3(&self.localBasisY2, &self.localBasisY1, &slerpM)
+	MathUtil.Vec3_mulMat3(&self.localBasisZ2, &self.localBasisZ1, &slerpM)
+}
+
+// computes cfm factor and erp of a row from the spring damper
+func (self *Joint) _getSpringDamperCoeffs(sd *SpringDamper, timeStep TimeStep) (cfmFactor, erp float64) {
+	omega := 2 * MathUtil.PI * sd.Frequency
+	zeta := sd.DampingRatio
+	if zeta < Settings.MinSpringDamperDampingRatio {
+		zeta = Settings.MinSpringDamperDampingRatio
+	}
+	h := timeStep.Dt
+	c := 2 * zeta * omega
+	k := omega * omega
+	if sd.UseSymplecticEuler {
+		cfmFactor = 1 / (h * c)
+		erp = k / c
+	} else {
+		cfmFactor = 1 / (h * (h*k + c))
+		erp = k / (h*k + c)
+	}
+	return
+}
+
+// sets impulse bounds and error of a limited row
+func (self *Joint) _setSolverInfoRowLimit(row *JointSolverInfoRow, diff, lower, upper, slop float64) (err float64) {
+	var minImp, maxImp float64
+	if lower > upper {
+		// inactive
+		minImp, maxImp, err = 0, 0, 0
+	} else if lower == upper {
+		// locked
+		minImp, maxImp = MathUtil.NEGATIVE_INFINITY, MathUtil.POSITIVE_INFINITY
+		err = diff - lower
+	} else if diff < lower {
+		// at lower limit
+		minImp, maxImp = MathUtil.NEGATIVE_INFINITY, 0
+		err = diff - lower + slop
+		if err > 0 {
+			err = 0
+		}
+	} else if diff > upper {
+		// at upper limit
+		minImp, maxImp = 0, MathUtil.POSITIVE_INFINITY
+		err = diff - upper - slop
+		if err < 0 {
+			err = 0
+		}
+	} else {
+		// inactive
+		minImp, maxImp, err = 0, 0, 0
+	}
+	row.minImpulse = minImp
+	row.maxImpulse = maxImp
+	return
+}
+
+// sets up a limited, motorized and sprung row. `motorForce` is the maximum force or torque of the motor.
+func (self *Joint) setSolverInfoRow(row *JointSolverInfoRow, diff, lower, upper, motorSpeed, motorForce, slop, mass float64, sd *SpringDamper, timeStep TimeStep, isPositionPart bool) {
+	var cfmFactor, erp float64
+
+	if isPositionPart {
+		cfmFactor = 0
+		erp = 1
+	} else {
+		if sd.Frequency > 0 {
+			slop = 0
+			cfmFactor, erp = self._getSpringDamperCoeffs(sd, timeStep)
+		} else {
+			cfmFactor = 0
+			erp = self.getErp(timeStep, false)
+		}
+		if motorForce > 0 {
+			row.motorSpeed = motorSpeed
+			row.motorMaxImpulse = motorForce * timeStep.Dt
+		} else {
+			row.motorSpeed = 0
+			row.motorMaxImpulse = 0
+		}
+	}
+
+	err := self._setSolverInfoRowLimit(row, diff, lower, upper, slop)
+
+	if mass == 0 {
+		row.cfm = 0
+	} else {
+		row.cfm = cfmFactor / mass
+	}
+	row.rhs = err * erp
+}
+
+// makes the motor of a row a servo, an implicit PD controller that pulls `err` to zero with the stiffness `k`, and damps
+// the speed toward `motorSpeed` with the damping `c`. Being implicit, it stays stable with high gains.
+func (self *Joint) _setSolverInfoRowServo(row *JointSolverInfoRow, err, motorSpeed, k, c float64, timeStep TimeStep) {
+	h := timeStep.Dt
+	denom := h*k + c
+	row.motorSpeed = (c*motorSpeed - k*err) / denom
+	row.motorCfm = 1 / (h * denom)
+}
+
+// wraps the angle into [-PI, PI)
+func _wrapAngle(angle float64) float64 {
+	return math.Mod(math.Mod(angle+MathUtil.PI, MathUtil.TWO_PI)+MathUtil.TWO_PI, MathUtil.TWO_PI) - MathUtil.PI
+}
+
+func (self *Joint) setSolverInfoRowLinear(row *JointSolverInfoRow, diff float64, lm *TranslationalLimitMotor, mass float64, sd *SpringDamper, timeStep TimeStep, isPositionPart bool) {
+	self.setSolverInfoRow(row, diff, lm.LowerLimit, lm.UpperLimit, lm.MotorSpeed, lm.MotorForce, Settings.LinearSlop, mass, sd, timeStep, isPositionPart)
+
+	if !isPositionPart && lm.MotorForce > 0 && (lm.ServoStiffness > 0 || lm.ServoDamping > 0) {
+		self._setSolverInfoRowServo(row, diff-lm.ServoTarget, lm.MotorSpeed, lm.ServoStiffness, lm.ServoDamping, timeStep)
+	}
+}
+
+func (self *Joint) setSolverInfoRowAngular(row *JointSolverInfoRow, diff float64, lm *RotationalLimitMotor, mass float64, sd *SpringDamper, timeStep TimeStep, isPositionPart bool) {
+	lower := lm.LowerLimit
+	upper := lm.UpperLimit
+
+	// wrap the angle into [mid - PI, mid + PI)
+	mid := (lower + upper) * 0.5
+	diff = _wrapAngle(diff-mid) + mid
+
+	self.setSolverInfoRow(row, diff, lower, upper, lm.MotorSpeed, lm.MotorTorque, Settings.AngularSlop, mass, sd, timeStep, isPositionPart)
+
+	if !isPositionPart && lm.MotorTorque > 0 && (lm.ServoStiffness > 0 || lm.ServoDamping > 0) {
+		self._setSolverInfoRowServo(row, _wrapAngle(diff-lm.ServoTarget), lm.MotorSpeed, lm.ServoStiffness, lm.ServoDamping, timeStep)
+	}
+}
+
+func (self *Joint) getErp(timeStep TimeStep, isPositionPart bool) float64 {
+	if isPositionPart {
+		return 1
+	}
+	if self.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+		return timeStep.InvDt * Settings.VelocityBaumgarte
+	}
+	return 0
+}
+
+// inverse of the effective moment of inertia of a body around `axis` through the anchor
+func _effectiveInvInertia(b *RigidBody, axis, relativeAnchor Vec3) float64 {
+	ia := axis.MulMat3(&b.invInertia)
+	invI := ia.Dot(axis)
+	if invI > 0 {
+		rsq := relativeAnchor.Dot(relativeAnchor)
+		dot := axis.Dot(relativeAnchor)
+		projsq := rsq - dot*dot
+		if projsq > 0 {
+			if b.invMass > 0 {
+				invI = 1 / (1/invI + b.mass*projsq)
+			} else {
+				invI = 0
+			}
+		}
+	}
+	return invI
+}
+
+func (self *Joint) computeEffectiveInertiaMoment(axis Vec3) float64 {
+	return self.computeEffectiveInertiaMoment2(axis, axis)
+}
+
+func (self *Joint) computeEffectiveInertiaMoment2(axis1, axis2 Vec3) float64 {
+	invI1 := _effectiveInvInertia(self.b1, axis1, self.relativeAnchor1)
+	invI2 := _effectiveInvInertia(self.b2, axis2, self.relativeAnchor2)
+	if invI1+invI2 == 0 {
+		return 0
+	}
+	return 1 / (invI1 + invI2)
+}
+
+// returns the rotation vector (axis * angle) that rotates the first basis to the second basis, in world coordinates
+func (self *Joint) computeAngularError() Vec3 {
+	var b1, b2, rel Mat3
+	var q Quat
+	MathUtil.Mat3_fromCols(&b1, &self.basisX1, &self.basisY1, &self.basisZ1)
+	MathUtil.Mat3_fromCols(&b2, &self.basisX2, &self.basisY2, &self.basisZ2)
+	MathUtil.Mat3_mulRhsTransposed(&rel, &b2, &b1)
+	MathUtil.Quat_fromMat3(&q, &rel)
+
+	// take the shorter way
+	if q.w < 0 {
+		q.x, q.y, q.z, q.w = -q.x, -q.y, -q.z, -q.w
+	}
+
+	axis := Vec3{q.x, q.y, q.z}
+	sin := axis.Length()
+	if sin == 0 {
+		return Vec3{}
+	}
+	angle := 2 * MathUtil.Atan2(sin, q.w)
+	return axis.Scale(angle / sin)
+}
+
+// decomposes the relative rotation between the bases into the swing rotation vector that rotates X1 to X2,
+// and the twist angle around X2
+func (self *Joint) computeSwingTwist() (swing Vec3, twist float64) {
+	var q Quat
+	var m Mat3
+	MathUtil.Quat_arc(&q, &self.basisX1, &self.basisX2)
+
+	axis := Vec3{q.x, q.y, q.z}
+	sin := axis.Length()
+	if sin > 0 {
+		swing = axis.Scale(2 * MathUtil.Atan2(sin, q.w) / sin)
+	}
+
+	// compare Y1 swung to the second basis with Y2
+	MathUtil.Mat3_fromQuat(&m, &q)
+	swungY1 := self.basisY1.MulMat3(&m)
+	cross := swungY1.Cross(self.basisY2)
+	twist = MathUtil.Atan2(cross.Dot(self.basisX2), swungY1.Dot(self.basisY2))
+	return
+}
+
+// decomposes the relative rotation between the bases into XYZ euler angles, and computes the axes along which the
+// relative angular velocity changes only the corresponding angle. The Y angle must be in [-PI/2, PI/2].
+func (self *Joint) computeEulerAngles() (angles, axisX, axisY, axisZ Vec3) {
+	x1 := self.basisX1
+	z2 := self.basisZ2
+
+	angles.x = MathUtil.Atan2(-self.basisY1.Dot(z2), self.basisZ1.Dot(z2))
+	angles.y = MathUtil.SafeAsin(x1.Dot(z2))
+	angles.z = MathUtil.Atan2(-x1.Dot(self.basisY2), x1.Dot(self.basisX2))
+
+	// the intermediate Y axis is perpendicular to both X1 and Z2
+	axisY = z2.Cross(x1)
+	if axisY.Dot(axisY) == 0 {
+		axisY = self.basisY1
+	} else {
+		axisY.Normalize()
+	}
+
+	// use the dual basis of (X1, Y, Z2)
+	yz2 := axisY.Cross(z2)
+	x1y := x1.Cross(axisY)
+	det := x1.Dot(yz2)
+	if det != 0 {
+		det = 1 / det
+	}
+	axisX = yz2.Scale(det)
+	axisZ = x1y.Scale(det)
+	return
+}
+
+// returns the speed of the second anchor relative to the first rigid body along `axis`, the rate the linear rows
+// along `axis` measure
+func (self *Joint) computeTranslationalSpeed(axis Vec3) float64 {
+	r1 := self.anchor2.Sub(self.b1.transform.position)
+	v1 := self.b1.angVel.Cross(r1)
+	v1.AddEq(self.b1.vel)
+	v2 := self.b2.angVel.Cross(self.relativeAnchor2)
+	v2.AddEq(self.b2.vel)
+	v2.SubEq(v1)
+	return v2.Dot(axis)
+}
+
+// returns the angular speed of the second rigid body relative to the first one about `axis`
+func (self *Joint) computeAngularSpeed(axis Vec3) float64 {
+	w := self.b2.angVel.Sub(self.b1.angVel)
+	return w.Dot(axis)
+}
+
+// sets the jacobian of a linear row along `axis` with the lever arms `r1` and `r2`
+func _setJacobianLinear(j *JacobianRow, axis, r1, r2 Vec3) {
+	j.lin1 = axis
+	j.lin2 = axis
+	j.ang1 = r1.Cross(axis)
+	j.ang2 = r2.Cross(axis)
+	j._updateSparsity()
+}
+
+// sets the jacobian of a row that constrains the relative translation of the anchors along `axis`.
+// The axis is assumed to be fixed to the first rigid body.
+func (self *Joint) setJacobianLinear(j *JacobianRow, axis Vec3) {
+	// the axis rotates with the first rigid body, so use the second anchor as the first body's lever
+	r1 := self.anchor2.Sub(self.b1.transform.position)
+	_setJacobianLinear(j, axis, r1, self.relativeAnchor2)
+}
+
+// adds three rows that make the anchor points coincide, using the first three impulses
+func (self *Joint) addSphericalRows(info *JointSolverInfo, erp float64) {
+	linearRhs := self.anchor2.Sub(self.anchor1)
+	linearRhs.ScaleEq(erp)
+
+	row := info.AddRow(&self.impulses[0])
+	row.EqualLimit(linearRhs.x, 0)
+	_setJacobianLinear(row.jacobian, Vec3{1, 0, 0}, self.relativeAnchor1, self.relativeAnchor2)
+
+	row = info.AddRow(&self.impulses[1])
+	row.EqualLimit(linearRhs.y, 0)
+	_setJacobianLinear(row.jacobian, Vec3{0, 1, 0}, self.relativeAnchor1, self.relativeAnchor2)
+
+	row = info.AddRow(&self.impulses[2])
+	row.EqualLimit(linearRhs.z, 0)
+	_setJacobianLinear(row.jacobian, Vec3{0, 0, 1}, self.relativeAnchor1, self.relativeAnchor2)
+}
+
+// sets the jacobian of a row that constrains the relative rotation around `axis`.
+func (self *Joint) setJacobianAngular(j *JacobianRow, axis Vec3) {
+	j.lin1.Zero()
+	j.lin2.Zero()
+	j.ang1 = axis
+	j.ang2 = axis
+	j._updateSparsity()
+}
+
 // --- internal ---
 
 // !! don't forget to call this from constraint solver !!
-func (self *Joint) syncAnchors() {
-	// TODO
-	panic("not impl")
+func (self *Joint) syncAnchors() { // override
+	tf1 := &self.b1.transform
+	tf2 := &self.b2.transform
+
+	// anchors
+	self.relativeAnchor1 = self.localAnchor1.MulMat3(&tf1.rotation)
+	self.relativeAnchor2 = self.localAnchor2.MulMat3(&tf2.rotation)
+	self.anchor1 = self.relativeAnchor1.Add(tf1.position)
+	self.anchor2 = self.relativeAnchor2.Add(tf2.position)
+
+	// bases
+	self.basisX1 = self.localBasisX1.MulMat3(&tf1.rotation)
+	self.basisY1 = self.localBasisY1.MulMat3(&tf1.rotation)
+	self.basisZ1 = self.localBasisZ1.MulMat3(&tf1.rotation)
+	self.basisX2 = self.localBasisX2.MulMat3(&tf2.rotation)
+	self.basisY2 = self.localBasisY2.MulMat3(&tf2.rotation)
+	self.basisZ2 = self.localBasisZ2.MulMat3(&tf2.rotation)
+}
+
+func (self *Joint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	info.b1 = self.b1
+	info.b2 = self.b2
+	info.numRows = 0
+}
+
+func (self *Joint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	info.b1 = self.b1
+	info.b2 = self.b2
+	info.numRows = 0
+}
+
+// removes the joint from the world if the constraint force or torque exceeds the break threshold
+func (self *Joint) checkDestruction() {
+	forceSq := self.appliedForce.LengthSq()
+	torqueSq := self.appliedTorque.LengthSq()
+
+	broken := self.breakForce > 0 && forceSq > self.breakForce*self.breakForce
+	broken = broken || (self.breakTorque > 0 && torqueSq > self.breakTorque*self.breakTorque)
+	if !broken || self.world == nil {
+		return
+	}
+
+	self.world.RemoveJoint(self)
+	if self.breakCallback != nil {
+		self.breakCallback.JointBroken(self, self.b1, self.b2, self.appliedForce, self.appliedTorque)
+	}
 }
 
 func (self *Joint) attachLinks() {
-	// TODO
-	panic("not impl")
+	self.b1.jointLinkList, self.b1.jointLinkListLast = DoubleList_push(self.b1.jointLinkList, self.b1.jointLinkListLast, self.link1)
+	self.b2.jointLinkList, self.b2.jointLinkListLast = DoubleList_push(self.b2.jointLinkList, self.b2.jointLinkListLast, self.link2)
+	self.b1.numJointLinks++
+	self.b2.numJointLinks++
+	self.link1.other = self.b2
+	self.link2.other = self.b1
+	self.b1.WakeUp()
+	self.b2.WakeUp()
 }
 
 func (self *Joint) detachLinks() {
-	// TODO
-	panic("not impl")
+	self.b1.jointLinkList, self.b1.jointLinkListLast = DoubleList_remove(self.b1.jointLinkList, self.b1.jointLinkListLast, self.link1)
+	self.b2.jointLinkList, self.b2.jointLinkListLast = DoubleList_remove(self.b2.jointLinkList, self.b2.jointLinkListLast, self.link2)
+	self.b1.numJointLinks--
+	self.b2.numJointLinks--
+	self.link1.other = nil
+	self.link2.other = nil
+	self.b1.WakeUp()
+	self.b2.WakeUp()
+}
+
+// --- public ---
+
+// Returns the first rigid body.
+func (self *Joint) GetRigidBody1() *RigidBody {
+	return self.b1
+}
+
+// Returns the second rigid body.
+func (self *Joint) GetRigidBody2() *RigidBody {
+	return self.b2
+}
+
+// Returns the type of the joint.
+// See `JointType` for details.
+func (self *Joint) GetType() JointType {
+	return self._type
+}
+
+// Returns the first rigid body's anchor point in world coordinates.
+func (self *Joint) GetAnchor1() Vec3 {
+	return self.anchor1
 }
 
-// TODO
+// Returns the second rigid body's anchor point in world coordinates.
+func (self *Joint) GetAnchor2() Vec3 {
+	return self.anchor2
+}
+
+// Sets `anchor` to the first rigid body's anchor point in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetAnchor1To(anchor *Vec3) {
+	*anchor = self.anchor1
+}
+
+// Sets `anchor` to the second rigid body's anchor point in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetAnchor2To(anchor *Vec3) {
+	*anchor = self.anchor2
+}
+
+// Returns the first rigid body's anchor point in local coordinates.
+func (self *Joint) GetLocalAnchor1() Vec3 {
+	return self.localAnchor1
+}
+
+// Returns the second rigid body's anchor point in local coordinates.
+func (self *Joint) GetLocalAnchor2() Vec3 {
+	return self.localAnchor2
+}
+
+// Sets `localAnchor` to the first rigid body's anchor point in local coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetLocalAnchor1To(localAnchor *Vec3) {
+	*localAnchor = self.localAnchor1
+}
+
+// Sets `localAnchor` to the second rigid body's anchor point in local coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetLocalAnchor2To(localAnchor *Vec3) {
+	*localAnchor = self.localAnchor2
+}
+
+// Returns the basis of the joint for the first rigid body in world coordinates.
+func (self *Joint) GetBasis1() Mat3 {
+	var b Mat3
+	MathUtil.Mat3_fromCols(&b, &self.basisX1, &self.basisY1, &self.basisZ1)
+	return b
+}
+
+// Returns the basis of the joint for the second rigid body in world coordinates.
+func (self *Joint) GetBasis2() Mat3 {
+	var b Mat3
+	MathUtil.Mat3_fromCols(&b, &self.basisX2, &self.basisY2, &self.basisZ2)
+	return b
+}
+
+// Sets `basis` to the basis of the joint for the first rigid body in world coordinates.
+// This does not create a new instance of `Mat3`.
+func (self *Joint) GetBasis1To(basis *Mat3) {
+	MathUtil.Mat3_fromCols(basis, &self.basisX1, &self.basisY1, &self.basisZ1)
+}
+
+// Sets `basis` to the basis of the joint for the second rigid body in world coordinates.
+// This does not create a new instance of `Mat3`.
+func (self *Joint) GetBasis2To(basis *Mat3) {
+	MathUtil.Mat3_fromCols(basis, &self.basisX2, &self.basisY2, &self.basisZ2)
+}
+
+// Returns whether to allow the connected rigid bodies to collide each other.
+func (self *Joint) GetAllowCollision() bool {
+	return self.allowCollision
+}
+
+// Sets whether to allow the connected rigid bodies to collide each other.
+func (self *Joint) SetAllowCollision(allowCollision bool) {
+	self.allowCollision = allowCollision
+}
+
+// Returns the magnitude of the constraint force at which the joint will be destroyed.
+// Returns `0` if the joint is unbreakable.
+func (self *Joint) GetBreakForce() float64 {
+	return self.breakForce
+}
+
+// Sets the magnitude of the constraint force at which the joint will be destroyed.
+// Set `0` for unbreakable joints.
+func (self *Joint) SetBreakForce(breakForce float64) {
+	self.breakForce = breakForce
+}
+
+// Returns the magnitude of the constraint torque at which the joint will be destroyed.
+// Returns `0` if the joint is unbreakable.
+func (self *Joint) GetBreakTorque() float64 {
+	return self.breakTorque
+}
+
+// Sets the magnitude of the constraint force at which the joint will be destroyed.
+// Set `0` for unbreakable joints.
+func (self *Joint) SetBreakTorque(breakTorque float64) {
+	self.breakTorque = breakTorque
+}
+
+// Returns the break callback of the joint.
+func (self *Joint) GetBreakCallback() IJointBreakCallback {
+	return self.breakCallback
+}
+
+// Sets the break callback of the joint.
+func (self *Joint) SetBreakCallback(callback IJointBreakCallback) {
+	self.breakCallback = callback
+}
+
+// Returns the type of the position correction algorithm for the joint.
+// See `PositionCorrectionAlgorithm` for details.
+func (self *Joint) GetPositionCorrectionAlgorithm() PositionCorrectionAlgorithm {
+	return self.positionCorrectionAlgorithm
+}
+
+// Sets the type of the position correction algorithm to `positionCorrectionAlgorithm` for the joint.
+// See `PositionCorrectionAlgorithm` for details.
+func (self *Joint) SetPositionCorrectionAlgorithm(positionCorrectionAlgorithm PositionCorrectionAlgorithm) {
+	switch positionCorrectionAlgorithm {
+	case PositionCorrectionAlgorithm_BAUMGARTE, PositionCorrectionAlgorithm_SPLIT_IMPULSE, PositionCorrectionAlgorithm_NGS:
+	default:
+		panic(fmt.Errorf("invalid position correction algorithm id: %d", positionCorrectionAlgorithm))
+	}
+	self.positionCorrectionAlgorithm = positionCorrectionAlgorithm
+}
+
+// Returns the force applied to the first rigid body at the last time step.
+func (self *Joint) GetAppliedForce() Vec3 {
+	return self.appliedForce
+}
+
+// Sets `appliedForce` to the force applied to the first rigid body at the last time step.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetAppliedForceTo(appliedForce *Vec3) {
+	*appliedForce = self.appliedForce
+}
+
+// Returns the torque applied to the first rigid body at the last time step.
+func (self *Joint) GetAppliedTorque() Vec3 {
+	return self.appliedTorque
+}
+
+// Sets `appliedTorque` to the torque applied to the first rigid body at the last time step.
+// This does not create a new instance of `Vec3`.
+func (self *Joint) GetAppliedTorqueTo(appliedTorque *Vec3) {
+	*appliedTorque = self.appliedTorque
+}
+
+// Returns the world the joint belongs to, or nil if the joint isn't added to a world.
+func (self *Joint) GetWorld() *World {
+	return self.world
+}
+
+// Returns the user data of the joint.
+func (self *Joint) GetUserData() any {
+	return self.userData
+}
+
+// Sets the user data of the joint.
+func (self *Joint) SetUserData(userData any) {
+	self.userData = userData
+}
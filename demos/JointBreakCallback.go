@@ -0,0 +1,20 @@
+package demos
+
+///////////////////////////////// JointBreakCallback
+// (goimo)
+// A callback interface for joint break events. A joint breaks when the magnitude of its constraint force or torque
+// exceeds the joint's break force or break torque. See `JointConfig.BreakForce` and `JointConfig.BreakTorque`.
+
+type IJointBreakCallback interface {
+	// This is called after the joint `j` is broken and removed from the world. `rigidBody1` and `rigidBody2` are the
+	// rigid bodies the joint connected, `force` and `torque` are the constraint force and torque applied to the first
+	// rigid body at the time step the joint broke.
+	JointBroken(j *Joint, rigidBody1, rigidBody2 *RigidBody, force, torque Vec3)
+}
+
+// A function adapter for `IJointBreakCallback`.
+type JointBreakCallbackFunc func(j *Joint, rigidBody1, rigidBody2 *RigidBody, force, torque Vec3)
+
+func (f JointBreakCallbackFunc) JointBroken(j *Joint, rigidBody1, rigidBody2 *RigidBody, force, torque Vec3) {
+	f(j, rigidBody1, rigidBody2, force, torque)
+}
@@ -6,47 +6,51 @@ package demos
 
 type JointConfig struct {
 	// The first rigid body attached to the joint.
-	rigidBody1 *RigidBody
+	RigidBody1 *RigidBody
 
 	// The second rigid body attached to the joint.
-	rigidBody2 *RigidBody
+	RigidBody2 *RigidBody
 
 	// The local position of the first rigid body's anchor point.
-	localAnchor1 Vec3
+	LocalAnchor1 Vec3
 
 	// The local position of the second rigid body's anchor point.
-	localAnchor2 Vec3
+	LocalAnchor2 Vec3
 
 	// Whether to allow the connected rigid bodies to collide each other.
-	allowCollision bool
+	AllowCollision bool
 
 	// The type of the constraint solver for the joint.
 	// See `ConstraintSolverType` for details.
-	solverType ConstraintSolverType
+	SolverType ConstraintSolverType
 
 	// The type of the position correction algorithm for the joint.
 	// See `PositionCorrectionAlgorithm` for details.
-	positionCorrectionAlgorithm PositionCorrectionAlgorithm
+	PositionCorrectionAlgorithm PositionCorrectionAlgorithm
 
 	// The joint will be destroyed when magnitude of the constraint force exceeds the value.
 	// Set `0` for unbreakable joints.
-	breakForce float64
+	BreakForce float64
 
 	// The joint will be destroyed when magnitude of the constraint torque exceeds the value.
 	// Set `0` for unbreakable joints.
-	breakTorque float64
+	BreakTorque float64
+
+	// The break callback of the joint. The callback is called when the joint is broken by exceeding `BreakForce` or
+	// `BreakTorque`.
+	BreakCallback IJointBreakCallback
 }
 
 func NewJointConfig() *JointConfig {
 	return &JointConfig{
-		solverType:                  Settings.DefaultJointConstraintSolverType,
-		positionCorrectionAlgorithm: Settings.DefaultJointPositionCorrectionAlgorithm,
+		SolverType:                  Settings.DefaultJointConstraintSolverType,
+		PositionCorrectionAlgorithm: Settings.DefaultJointPositionCorrectionAlgorithm,
 	}
 }
 
 func (j *JointConfig) init(rb1, rb2 *RigidBody, worldAnchor Vec3) {
-	j.rigidBody1 = rb1
-	j.rigidBody2 = rb2
-	j.rigidBody1.GetLocalPointTo(worldAnchor, &j.localAnchor1)
-	j.rigidBody2.GetLocalPointTo(worldAnchor, &j.localAnchor2)
+	j.RigidBody1 = rb1
+	j.RigidBody2 = rb2
+	j.RigidBody1.GetLocalPointTo(worldAnchor, &j.LocalAnchor1)
+	j.RigidBody2.GetLocalPointTo(worldAnchor, &j.LocalAnchor2)
 }
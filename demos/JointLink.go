@@ -17,4 +17,32 @@ func NewJointLink(joint *Joint) *JointLink {
 	}
 }
 
-// TODO
+// --- double linked list interface ---
+
+func (c *JointLink) GetNext() *JointLink {
+	return c.next
+}
+
+func (c *JointLink) SetNext(x *JointLink) {
+	c.next = x
+}
+
+func (c *JointLink) GetPrev() *JointLink {
+	return c.prev
+}
+
+func (c *JointLink) SetPrev(x *JointLink) {
+	c.prev = x
+}
+
+// --- public ---
+
+// Returns the joint of the link.
+func (self *JointLink) GetJoint() *Joint {
+	return self.joint
+}
+
+// Returns the other rigid body of the link. This provides a quick access from a rigid body to the other one of the joint.
+func (self *JointLink) GetOther() *RigidBody {
+	return self.other
+}
@@ -26,6 +26,9 @@ type JointSolverInfoRow struct {
 	// Used for velocity solver.
 	motorMaxImpulse float64
 
+	// Used for velocity solver. Non-zero for servo motors.
+	motorCfm float64
+
 	// Used for both velocity and position solver.
 	impulse *JointImpulse
 }
@@ -40,6 +43,7 @@ func (j *JointSolverInfoRow) Clear() {
 	j.jacobian.Clear()
 	j.rhs, j.cfm = 0, 0
 	j.minImpulse, j.maxImpulse, j.motorSpeed, j.motorMaxImpulse = 0, 0, 0, 0
+	j.motorCfm = 0
 	j.impulse = nil
 }
 
@@ -13,6 +13,7 @@ type JointSolverMassDataRow struct {
 
 	mass           float64
 	massWithoutCfm float64
+	motorMass      float64
 }
 
 func NewJointSolverMassDataRow() *JointSolverMassDataRow {
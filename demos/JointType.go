@@ -0,0 +1,21 @@
+package demos
+
+///////////////////////////////// JointType
+// (oimo/dynamics/constraint/joint/JointType.go)
+// The list of the types of the joints.
+
+type JointType int
+
+const (
+	JointType_SPHERICAL JointType = iota
+	JointType_REVOLUTE
+	JointType_CYLINDRICAL
+	JointType_PRISMATIC
+	JointType_UNIVERSAL
+	JointType_RAGDOLL
+	JointType_GENERIC
+	JointType_DISTANCE
+	JointType_GEAR
+	JointType_PULLEY
+	JointType_MOUSE
+)
@@ -0,0 +1,128 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+// creates a world without gravity, with a static box and a dynamic unit box both at the origin
+func jointTestWorld() (*World, *RigidBody, *RigidBody) {
+	w := NewWorld(BroadPhaseType_BVH, &Vec3{})
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	base := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	base.AddShape(NewShape(sc))
+	w.AddRigidBody(base)
+
+	rc = NewRigidBodyConfig()
+	box := NewRigidBody(rc)
+	box.AddShape(NewShape(sc))
+	w.AddRigidBody(box)
+	return w, base, box
+}
+
+func TestJointBreak(t *testing.T) {
+	w := groundTestWorld()
+
+	// each joint holds a weight to its own static anchor, so that the torque is measured about the anchor
+	anchor := func(position Vec3) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Type = RigidBodyType_STATIC
+		rc.Position = position
+		rb := NewRigidBody(rc)
+		w.AddRigidBody(rb)
+		return rb
+	}
+	weight := func(position Vec3, density float64) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = position
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.25, 0.25, 0.25})
+		sc.Density = density
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+	var broken []*Joint
+	torques := map[*Joint]Vec3{}
+	callback := JointBreakCallbackFunc(func(j *Joint, rigidBody1, rigidBody2 *RigidBody, force, torque Vec3) {
+		broken = append(broken, j)
+		torques[j] = torque
+	})
+
+	// a light and a heavy weight hang from sliders, only the slider of the heavy one breaks its force of about 123 N
+	lightSlider := anchor(Vec3{-4, 5, 0})
+	light := weight(Vec3{-4, 4, 0}, 1)
+	pc := NewPrismaticJointConfig().Init(lightSlider, light, Vec3{-4, 5, 0}, Vec3{1, 0, 0})
+	pc.BreakForce = 50
+	pc.BreakCallback = callback
+	j1 := NewPrismaticJoint(pc)
+	w.AddJoint(j1.Joint)
+
+	heavySlider := anchor(Vec3{0, 5, 0})
+	heavy := weight(Vec3{0, 4, 0}, 100)
+	pc = NewPrismaticJointConfig().Init(heavySlider, heavy, Vec3{0, 5, 0}, Vec3{1, 0, 0})
+	pc.BreakForce = 50
+	pc.BreakCallback = callback
+	j2 := NewPrismaticJoint(pc)
+	w.AddJoint(j2.Joint)
+
+	// a heavy weight on a 1 m lever is held by a slider, which breaks its torque of about 123 N m
+	leverSlider := anchor(Vec3{4, 5, 0})
+	lever := weight(Vec3{5, 5, 0}, 100)
+	pc = NewPrismaticJointConfig().Init(leverSlider, lever, Vec3{4, 5, 0}, Vec3{1, 0, 0})
+	pc.BreakTorque = 100
+	pc.BreakCallback = callback
+	j3 := NewPrismaticJoint(pc)
+	w.AddJoint(j3.Joint)
+
+	stepWorld(w, 10)
+	if len(broken) != 2 || broken[0] == j1.Joint || broken[1] == j1.Joint {
+		t.Fatalf("broke %v joints", len(broken))
+	}
+	if j2.GetWorld() != nil || j3.GetWorld() != nil || j1.GetWorld() != w || w.GetNumJoints() != 1 {
+		t.Fatalf("%v joints left in the world", w.GetNumJoints())
+	}
+	if torque := torques[j3.Joint]; torque.Length() <= 100 {
+		t.Fatalf("the slider broke at a torque of %v", torque)
+	}
+	if heavy.GetPosition().y > 3.9 || lever.GetPosition().y > 4.95 || light.GetPosition().y < 3.99 {
+		t.Fatalf("the weights are at %v, %v and %v", light.GetPosition(), heavy.GetPosition(), lever.GetPosition())
+	}
+}
+
+func TestServoTargets(t *testing.T) {
+	w, base, box := jointTestWorld()
+	jc := NewRevoluteJointConfig().Init(base, box, Vec3{}, Vec3{0, 0, 1})
+	jc.LimitMotor.SetMotor(0, 100).SetServo(1, 10, 2)
+	hinge := NewRevoluteJoint(jc)
+	w.AddJoint(hinge.Joint)
+
+	w2, base2, box2 := jointTestWorld()
+	pc := NewPrismaticJointConfig().Init(base2, box2, Vec3{}, Vec3{1, 0, 0})
+	pc.LimitMotor.SetMotor(0, 100).SetServo(-0.5, 40, 8)
+	slider := NewPrismaticJoint(pc)
+	w2.AddJoint(slider.Joint)
+
+	stepWorld(w, 180)
+	stepWorld(w2, 180)
+	if a := hinge.GetAngle(); math.Abs(a-1) > 0.01 {
+		t.Fatalf("the hinge settled at %v", a)
+	}
+	if tr := slider.GetTranslation(); math.Abs(tr+0.5) > 0.01 {
+		t.Fatalf("the slider settled at %v", tr)
+	}
+
+	// the motor torque caps the servo, 0.1 N m speeds the box of the moment of inertia 1/6 up by about 0.01 in a step,
+	// where the uncapped servo would by about 2
+	hinge.GetLimitMotor().SetServo(3, 10, 2)
+	hinge.GetLimitMotor().MotorTorque = 0.1
+	box.WakeUp()
+	stepWorld(w, 1)
+	if s := box.GetAngularVelocity(); s.z > 0.02 {
+		t.Fatalf("the servo sped up to %v", s.z)
+	}
+}
@@ -65,6 +65,18 @@ func (self *Manifold) buildBasis(normal Vec3) {
 	self.binormal = Vec3{bx, by, bz}
 }
 
+// Rotates the tangent and the binormal about the normal so that the tangent is along `direction` projected onto the
+// contact plane. The basis is not changed if `direction` is almost parallel to the normal.
+func (self *Manifold) alignBasis(direction Vec3) {
+	t := direction.AddRhsScaled(self.normal, -direction.Dot(self.normal))
+	lenSq := t.Dot(t)
+	if lenSq < 1e-12 {
+		return
+	}
+	self.tangent = t.Scale(1 / MathUtil.Sqrt(lenSq))
+	self.binormal = self.normal.Cross(self.tangent)
+}
+
 func (self *Manifold) updateDepthsAndPositions(tf1, tf2 *Transform) {
 	for i := range self.numPoints {
 		p := self.points[i]
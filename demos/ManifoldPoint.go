@@ -24,6 +24,9 @@ type ManifoldPoint struct {
 	// manifold points can be disabled for some reasons (separated, etc...)
 	disabled bool
 
+	// disabled by the user for this step
+	ignored bool
+
 	id int
 }
 
@@ -46,6 +49,7 @@ func (self *ManifoldPoint) clear() {
 	self.impulse.clear()
 	self.warmStarted = false
 	self.disabled = false
+	self.ignored = false
 	self.id = -1
 }
 
@@ -69,6 +73,7 @@ func (self *ManifoldPoint) initialize(result *DetectorResultPoint, tf1, tf2 *Tra
 	self.id = result.id
 	self.warmStarted = false
 	self.disabled = false
+	self.ignored = false
 }
 
 func (self *ManifoldPoint) updateDepthAndPositions(result *DetectorResultPoint, tf1, tf2 *Transform) {
@@ -99,6 +104,7 @@ func (self *ManifoldPoint) copyFrom(cp *ManifoldPoint) {
 	self.id = cp.id
 	self.warmStarted = cp.warmStarted
 	self.disabled = false
+	self.ignored = cp.ignored
 }
 
 // --- public ---
@@ -152,3 +158,9 @@ func (self *ManifoldPoint) GetBinormalImpulse() float64 {
 func (self *ManifoldPoint) IsEnabled() bool {
 	return !self.disabled
 }
+
+// Disables the manifold point for this step. A disabled manifold point applies no impulses. Call this in
+// `IContactCallback.PreSolve`, the manifold point is enabled again in the next step.
+func (self *ManifoldPoint) Disable() {
+	self.ignored = true
+}
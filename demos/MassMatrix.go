@@ -1,5 +1,7 @@
 package demos
 
+import "math"
+
 //////////////////////////////////////// MassMatrix
 // (oimo/dynamics/constraint/solver/direct/MassMatrix.go)
 // Internal class.
@@ -57,4 +59,141 @@ func NewMassMatrix(size int) *MassMatrix {
 	return mm
 }
 
-// TODO
+// Computes the inverse mass matrix of the rows of `info`, and sets the mass data of each row into `massData`.
+func (self *MassMatrix) computeInvMass(info *JointSolverInfo, massData []*JointSolverMassDataRow) {
+	numRows := info.numRows
+	b1 := info.b1
+	b2 := info.b2
+	invM1 := b1.invMass
+	invM2 := b2.invMass
+	invI1 := b1.invInertia
+	invI2 := b2.invInertia
+
+	// compute invM1 * J1^T and invM2 * J2^T
+	for i := range numRows {
+		j := info.rows[i].jacobian
+		md := massData[i]
+		j._updateSparsity()
+
+		if j.IsLinearSet() {
+			md.invMLin1 = j.lin1.Scale(invM1)
+			md.invMLin2 = j.lin2.Scale(invM2)
+		} else {
+			md.invMLin1.Zero()
+			md.invMLin2.Zero()
+		}
+
+		if j.IsAngularSet() {
+			md.invMAng1 = j.ang1.MulMat3(&invI1)
+			md.invMAng2 = j.ang2.MulMat3(&invI2)
+		} else {
+			md.invMAng1.Zero()
+			md.invMAng2.Zero()
+		}
+	}
+
+	// compute J1 * invM1 * J1^T + J2 * invM2 * J2^T
+	for i := range numRows {
+		j1 := info.rows[i].jacobian
+		for j := i; j < numRows; j++ {
+			md2 := massData[j]
+			val := j1.lin1.Dot(md2.invMLin1) + j1.ang1.Dot(md2.invMAng1) + j1.lin2.Dot(md2.invMLin2) + j1.ang2.Dot(md2.invMAng2)
+
+			if i == j {
+				cfm := info.rows[i].cfm
+				self.invMass[i][j] = val + cfm
+				self.invMassWithoutCfm[i][j] = val
+
+				md2.mass = val + cfm
+				md2.massWithoutCfm = val
+				md2.motorMass = val + info.rows[i].motorCfm
+				if md2.mass != 0 {
+					md2.mass = 1 / md2.mass
+				}
+				if md2.massWithoutCfm != 0 {
+					md2.massWithoutCfm = 1 / md2.massWithoutCfm
+				}
+				if md2.motorMass != 0 {
+					md2.motorMass = 1 / md2.motorMass
+				}
+			} else {
+				self.invMass[i][j] = val
+				self.invMass[j][i] = val
+				self.invMassWithoutCfm[i][j] = val
+				self.invMassWithoutCfm[j][i] = val
+			}
+		}
+	}
+
+	// clear cache
+	for i := range self.maxSubatrixId {
+		self.cachedComputed[i] = false
+	}
+}
+
+// Returns the inverse of the submatrix of the inverse mass matrix consisting of `size` rows and columns in `indices`.
+func (self *MassMatrix) getSubmatrix(indices []int, size int) [][]float64 {
+	id := 0
+	for i := range size {
+		id |= 1 << indices[i]
+	}
+	if !self.cachedComputed[id] {
+		self.computeSubmatrix(id, indices, size)
+		self.cachedComputed[id] = true
+	}
+	return self.cachedSubmatrices[id]
+}
+
+// computes the inverse of the submatrix by Gauss-Jordan elimination
+func (self *MassMatrix) computeSubmatrix(id int, indices []int, size int) {
+	src := self.tmpMatrix
+	dst := self.cachedSubmatrices[id]
+
+	// copy the submatrix, and set dst identity
+	for i := range size {
+		ii := indices[i]
+		for j := range size {
+			src[i][j] = self.invMass[ii][indices[j]]
+			dst[i][j] = 0
+		}
+		dst[i][i] = 1
+	}
+
+	for i := range size {
+		// partial pivoting
+		pivot := i
+		for j := i + 1; j < size; j++ {
+			if math.Abs(src[j][i]) > math.Abs(src[pivot][i]) {
+				pivot = j
+			}
+		}
+		src[i], src[pivot] = src[pivot], src[i]
+		dst[i], dst[pivot] = dst[pivot], dst[i]
+
+		inv := src[i][i]
+		if inv == 0 {
+			// singular, the row has no effect
+			continue
+		}
+		inv = 1 / inv
+		for j := range size {
+			src[i][j] *= inv
+			dst[i][j] *= inv
+		}
+
+		// eliminate the column from the other rows
+		for k := range size {
+			if k == i {
+				continue
+			}
+			f := src[k][i]
+			if f == 0 {
+				continue
+			}
+			for j := range size {
+				src[k][j] -= f * src[i][j]
+				dst[k][j] -= f * dst[i][j]
+			}
+		}
+	}
+}
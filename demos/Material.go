@@ -0,0 +1,70 @@
+package demos
+
+//////////////////////////////////////////////// Material
+// (goimo)
+// A material holds the surface properties of shapes. A material can be shared by any number of shapes, and changes
+// to its fields take effect from the next step. The coefficients of two materials in contact are combined by their
+// combine modes, unless a material pair is registered to the world through `World.SetMaterialPair`.
+
+type Material struct {
+	// The coefficient of friction while the surfaces stick to each other.
+	StaticFriction float64
+
+	// The coefficient of friction while the surfaces slide over each other.
+	DynamicFriction float64
+
+	// The coefficient of restitution.
+	Restitution float64
+
+	// The coefficient of rolling resistance in meters. The torque resisting rolling is at most this times the normal
+	// force. `0` disables rolling resistance.
+	RollingFriction float64
+
+	// The coefficient of torsional friction in meters. The torque resisting spinning about the contact normal is at
+	// most this times the normal force. `0` disables torsional friction.
+	TorsionalFriction float64
+
+	// How the coefficients of friction, rolling resistance and torsional friction of two materials are combined.
+	FrictionCombineMode MaterialCombineMode
+
+	// How the coefficients of restitution of two materials are combined.
+	RestitutionCombineMode MaterialCombineMode
+}
+
+func NewMaterial() *Material {
+	return &Material{
+		StaticFriction:         Settings.DefaultFriction,
+		DynamicFriction:        Settings.DefaultFriction,
+		Restitution:            Settings.DefaultRestitution,
+		FrictionCombineMode:    MaterialCombineMode_GEOMETRIC_MEAN,
+		RestitutionCombineMode: MaterialCombineMode_GEOMETRIC_MEAN,
+	}
+}
+
+// Sets the coefficients at once and returns `this`.
+func (self *Material) Init(staticFriction, dynamicFriction, restitution float64) *Material {
+	self.StaticFriction = staticFriction
+	self.DynamicFriction = dynamicFriction
+	self.Restitution = restitution
+	return self
+}
+
+// Sets the coefficients of rolling resistance and torsional friction and returns `this`.
+func (self *Material) SetRollingAndTorsionalFriction(rollingFriction, torsionalFriction float64) *Material {
+	self.RollingFriction = rollingFriction
+	self.TorsionalFriction = torsionalFriction
+	return self
+}
+
+// Sets the combine modes of friction and restitution and returns `this`.
+func (self *Material) SetCombineModes(frictionCombineMode, restitutionCombineMode MaterialCombineMode) *Material {
+	self.FrictionCombineMode = frictionCombineMode
+	self.RestitutionCombineMode = restitutionCombineMode
+	return self
+}
+
+// Returns a clone of the object
+func (self *Material) Clone() *Material {
+	m := *self
+	return &m
+}
@@ -0,0 +1,31 @@
+package demos
+
+//////////////////////////////////////////////// MaterialCombineMode
+// (goimo)
+// The list of the ways to combine the coefficients of two materials. When two materials use different modes, the
+// mode listed later is used.
+
+type MaterialCombineMode int
+
+const (
+	MaterialCombineMode_GEOMETRIC_MEAN MaterialCombineMode = iota // sqrt(a * b)
+	MaterialCombineMode_AVERAGE                                   // (a + b) / 2
+	MaterialCombineMode_MIN                                       // min(a, b)
+	MaterialCombineMode_MULTIPLY                                  // a * b
+	MaterialCombineMode_MAX                                       // max(a, b)
+)
+
+// Returns the coefficient combined from `a` and `b` with the mode `mode`.
+func _combineCoefficients(mode MaterialCombineMode, a, b float64) float64 {
+	switch mode {
+	case MaterialCombineMode_AVERAGE:
+		return (a + b) * 0.5
+	case MaterialCombineMode_MIN:
+		return min(a, b)
+	case MaterialCombineMode_MULTIPLY:
+		return a * b
+	case MaterialCombineMode_MAX:
+		return max(a, b)
+	}
+	return MathUtil.Sqrt(a * b)
+}
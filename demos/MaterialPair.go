@@ -0,0 +1,44 @@
+package demos
+
+//////////////////////////////////////////////// MaterialPair
+// (goimo)
+// A material pair holds the coefficients used for the contacts between two specific materials, instead of the ones
+// combined from the materials. See `World.SetMaterialPair`.
+
+type MaterialPair struct {
+	StaticFriction  float64 // The coefficient of friction while the surfaces stick to each other.
+	DynamicFriction float64 // The coefficient of friction while the surfaces slide over each other.
+	Restitution     float64 // The coefficient of restitution.
+
+	RollingFriction   float64 // The coefficient of rolling resistance in meters.
+	TorsionalFriction float64 // The coefficient of torsional friction in meters.
+}
+
+func NewMaterialPair() *MaterialPair {
+	return &MaterialPair{
+		StaticFriction:  Settings.DefaultFriction,
+		DynamicFriction: Settings.DefaultFriction,
+		Restitution:     Settings.DefaultRestitution,
+	}
+}
+
+// Sets the coefficients at once and returns `this`.
+func (self *MaterialPair) Init(staticFriction, dynamicFriction, restitution float64) *MaterialPair {
+	self.StaticFriction = staticFriction
+	self.DynamicFriction = dynamicFriction
+	self.Restitution = restitution
+	return self
+}
+
+// Sets the coefficients of rolling resistance and torsional friction and returns `this`.
+func (self *MaterialPair) SetRollingAndTorsionalFriction(rollingFriction, torsionalFriction float64) *MaterialPair {
+	self.RollingFriction = rollingFriction
+	self.TorsionalFriction = torsionalFriction
+	return self
+}
+
+// the key of a material pair in the table of the world
+type materialPairKey struct {
+	m1 *Material
+	m2 *Material
+}
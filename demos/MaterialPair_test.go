@@ -0,0 +1,43 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMaterialPairOverride(t *testing.T) {
+	w := groundTestWorld()
+	groundMaterial := w.GetRigidBodyList().GetShapeList().GetMaterial()
+	box := func(z float64, material *Material) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{0, 1, z}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		sc.Material = material
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		rb.SetLinearVelocity(Vec3{3, 0, 0})
+		return rb
+	}
+	// the same coefficients, but the pair of the second material and the ground has no friction
+	rough := box(-2, NewMaterial().Init(1, 1, 0))
+	slippery := NewMaterial().Init(1, 1, 0)
+	overridden := box(2, slippery)
+	w.SetMaterialPair(groundMaterial, slippery, NewMaterialPair().Init(0, 0, 0))
+
+	stepWorld(w, 60)
+	if v := rough.GetLinearVelocity(); v.x > 0.1 {
+		t.Fatalf("the rough box slides at %v", v.x)
+	}
+	if v := overridden.GetLinearVelocity(); math.Abs(v.x-3) > 0.01 {
+		t.Fatalf("the box of the overridden pair slides at %v", v.x)
+	}
+
+	// removing the pair brings the friction back
+	w.SetMaterialPair(slippery, groundMaterial, nil)
+	stepWorld(w, 60)
+	if v := overridden.GetLinearVelocity(); v.x > 0.1 {
+		t.Fatalf("the box of the removed pair slides at %v", v.x)
+	}
+}
@@ -27,8 +27,17 @@ var MathUtil = MathUtilNamespace{
 	TO_DEGREES:        180.0 / math.Pi,
 }
 
+var mathUtilRand = rand.New(rand.NewSource(0))
+
+// Returns a random number from `0` inclusive to `1` exclusive. The sequence is the same every run unless reseeded with
+// `SetRandSeed`. This is not safe for concurrent use.
 func (MathUtilNamespace) Rand() float64 {
-	return rand.Float64()
+	return mathUtilRand.Float64()
+}
+
+// Seeds the random numbers returned by `Rand` with `seed`.
+func (MathUtilNamespace) SetRandSeed(seed int64) {
+	mathUtilRand.Seed(seed)
 }
 
 // Returns a random `Vec3` from `(min, min, min)` inclusive to `(max, max, max)` exclusive.
@@ -52,6 +61,49 @@ func (MathUtilNamespace) Sqrt(x float64) float64 {
 	return math.Sqrt(x)
 }
 
+// Returns `Math.atan2(y, x)`.
+func (MathUtilNamespace) Atan2(y, x float64) float64 {
+	return math.Atan2(y, x)
+}
+
+// Returns `Math.acos(x)`, clamping `x` to [-1, 1] first.
+func (MathUtilNamespace) SafeAcos(x float64) float64 {
+	if x <= -1 {
+		return MathUtil.PI
+	}
+	if x >= 1 {
+		return 0
+	}
+	return math.Acos(x)
+}
+
+// Returns `Math.asin(x)`, clamping `x` to [-1, 1] first.
+func (MathUtilNamespace) SafeAsin(x float64) float64 {
+	if x <= -1 {
+		return -MathUtil.HALF_PI
+	}
+	if x >= 1 {
+		return MathUtil.HALF_PI
+	}
+	return math.Asin(x)
+}
+
+// Returns `Math.floor(x)`.
+func (MathUtilNamespace) Floor(x float64) float64 {
+	return math.Floor(x)
+}
+
+// Returns `x` clamped to [min, max]
+func (MathUtilNamespace) Clamp(x, min, max float64) float64 {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
 // Returns (float64) -1.0 if x<0, 1.0 otherwise
 func (MathUtilNamespace) Sign(x float64) float64 {
 	if x < 0 {
@@ -184,6 +236,22 @@ func (MathUtilNamespace) Vec3_abs(dst *Vec3, src *Vec3) {
 	dst.z = math.Abs(src.z)
 }
 
+// Stores a unit vector perpendicular to src into dst
+func (MathUtilNamespace) Vec3_perp(dst *Vec3, src *Vec3) {
+	x, y, z := src.x, src.y, src.z
+	x2, y2, z2 := x*x, y*y, z*z
+	if x2 < y2 && x2 < z2 {
+		invL := 1.0 / MathUtil.Sqrt(y2+z2)
+		dst.x, dst.y, dst.z = 0, z*invL, -y*invL
+	} else if x2 >= y2 && y2 < z2 {
+		invL := 1.0 / MathUtil.Sqrt(z2+x2)
+		dst.x, dst.y, dst.z = -z*invL, 0, x*invL
+	} else {
+		invL := 1.0 / MathUtil.Sqrt(x2+y2)
+		dst.x, dst.y, dst.z = y*invL, -x*invL, 0
+	}
+}
+
 // /////////////////////////////////////// Quat
 
 // Creates Quat from x,y,z of src1 Vec3 and w from src2 float
@@ -226,6 +294,24 @@ func (MathUtilNamespace) Quat_fromMat3(dst *Quat, m *Mat3) {
 	}
 }
 
+// Creates the shortest arc rotation Quat that rotates unit vector src1 to unit vector src2
+func (MathUtilNamespace) Quat_arc(dst *Quat, src1 *Vec3, src2 *Vec3) {
+	d := src1.Dot(*src2)
+
+	if d < -1+1e-9 {
+		// PI rotation around any perpendicular axis
+		var perp Vec3
+		MathUtil.Vec3_perp(&perp, src1)
+		dst.x, dst.y, dst.z, dst.w = perp.x, perp.y, perp.z, 0
+		return
+	}
+
+	c := src1.Cross(*src2)
+	w := MathUtil.Sqrt((1 + d) * 0.5)
+	d = 0.5 / w
+	dst.x, dst.y, dst.z, dst.w = c.x*d, c.y*d, c.z*d, w
+}
+
 // Multiplies 2 quats
 func (MathUtilNamespace) Quat_mul(dst *Quat, src1 *Quat, src2 *Quat) {
 	ax, ay, az, aw := src1.x, src1.y, src1.z, src1.w
@@ -246,7 +332,7 @@ func (MathUtilNamespace) Quat_mul(dst *Quat, src1 *Quat, src2 *Quat) {
 func (MathUtilNamespace) Quat_normalize(dst *Quat, src *Quat) {
 	l := MathUtil.Quat_lengthSq(src)
 	if l > 1e-32 {
-		l = 1.0 / MathUtil.Sqrt(1.0)
+		l = 1.0 / MathUtil.Sqrt(l)
 	}
 	MathUtil.Quat_scale(dst, src, l)
 }
@@ -264,6 +350,31 @@ func (MathUtilNamespace) Quat_scale(dst *Quat, src1 *Quat, src2 float64) {
 	dst.w = src1.w * src2
 }
 
+// Spherical linear interpolation from unit quat src1 (t = 0) to unit quat src2 (t = 1) along the shortest arc
+func (MathUtilNamespace) Quat_slerp(dst *Quat, src1 *Quat, src2 *Quat, t float64) {
+	qx, qy, qz, qw := src2.x, src2.y, src2.z, src2.w
+	d := src1.x*qx + src1.y*qy + src1.z*qz + src1.w*qw
+	if d < 0 {
+		d = -d
+		qx, qy, qz, qw = -qx, -qy, -qz, -qw
+	}
+
+	k1 := 1 - t
+	k2 := t
+	if d < 1-1e-6 {
+		theta := MathUtil.SafeAcos(d)
+		invSin := 1.0 / MathUtil.Sin(theta)
+		k1 = MathUtil.Sin(k1*theta) * invSin
+		k2 = MathUtil.Sin(k2*theta) * invSin
+	}
+
+	dst.x = src1.x*k1 + qx*k2
+	dst.y = src1.y*k1 + qy*k2
+	dst.z = src1.z*k1 + qz*k2
+	dst.w = src1.w*k1 + qw*k2
+	MathUtil.Quat_normalize(dst, dst)
+}
+
 // /////////////////////////////////////// Mat3
 
 // Set rotation dst Mat3 from given src Quat
@@ -294,6 +405,15 @@ func (MathUtilNamespace) Mat3_fromEulerXyz(dst *Mat3, src *Vec3) {
 	dst.e20, dst.e21, dst.e22 = sx*sz-cx*cz*sy, cz*sx+cx*sy*sz, cx*cy
 }
 
+// Sets columns of dst to c0, c1 and c2
+func (MathUtilNamespace) Mat3_fromCols(dst *Mat3, c0, c1, c2 *Vec3) {
+	dst.Set(
+		c0.x, c1.x, c2.x,
+		c0.y, c1.y, c2.y,
+		c0.z, c1.z, c2.z,
+	)
+}
+
 // Sets diagonal values and zero the rest
 func (MathUtilNamespace) Mat3_diagonal(dst *Mat3, x, y, z float64) {
 	dst.e00, dst.e01, dst.e02 = x, 0, 0
@@ -0,0 +1,124 @@
+package demos
+
+//////////////////////////////////////////////// MouseJoint
+// (goimo)
+// A mouse joint (a.k.a. target joint) pulls the second rigid body's anchor point toward a target point in world
+// coordinates with a soft spring, and is used for dragging rigid bodies around. The first rigid body is a static or
+// kinematic body the joint is attached to, and only the target position matters. The rotation of the second rigid
+// body is not constrained. The joint is solved only in the velocity part, so the pull is always limited by the
+// maximum force.
+
+type MouseJoint struct {
+	*Joint
+
+	sd       *SpringDamper
+	maxForce float64
+
+	// the maximum length of the impulse of a step, computed in getInfo
+	maxImpulse float64
+
+	target Vec3
+}
+
+// Creates a new mouse joint by configuration `config`.
+func NewMouseJoint(config *MouseJointConfig) *MouseJoint {
+	j := &MouseJoint{
+		Joint:    NewJoint(config.JointConfig, JointType_MOUSE),
+		sd:       config.SpringDamper.Clone(),
+		maxForce: config.MaxForce,
+		target:   config.Target,
+	}
+	j.impl = j
+	j.solver = NewMouseJointConstraintSolver(j)
+	return j
+}
+
+func (self *MouseJoint) getInfo(info *JointSolverInfo, timeStep TimeStep) {
+	mass := self.b2.invMass
+	if mass != 0 {
+		mass = 1 / mass
+	}
+
+	var cfmFactor, erp float64
+	if self.sd.Frequency > 0 {
+		cfmFactor, erp = self._getSpringDamperCoeffs(self.sd, timeStep)
+	} else {
+		cfmFactor = 0
+		erp = timeStep.InvDt * Settings.VelocityBaumgarte
+	}
+
+	cfm := 0.0
+	if mass != 0 {
+		cfm = cfmFactor / mass
+	}
+
+	// the impulse is clamped as a vector by the solver
+	self.maxImpulse = MathUtil.POSITIVE_INFINITY
+	if self.maxForce > 0 {
+		self.maxImpulse = self.maxForce * timeStep.Dt
+	}
+
+	diff := self.anchor2.Sub(self.anchor1)
+	axes := [3]Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	errs := [3]float64{diff.x, diff.y, diff.z}
+	for i := range 3 {
+		row := info.AddRow(&self.impulses[i])
+		row.EqualLimit(errs[i]*erp, cfm)
+		_setJacobianLinear(row.jacobian, axes[i], self.relativeAnchor1, self.relativeAnchor2)
+	}
+}
+
+// --- internal ---
+
+func (self *MouseJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+
+	// the target is fixed in world coordinates
+	self.anchor1 = self.target
+	self.relativeAnchor1 = self.target.Sub(self.b1.transform.position)
+}
+
+func (self *MouseJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep)
+}
+
+func (self *MouseJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	// no position correction, the pull is soft
+	self.Joint.getPositionSolverInfo(info)
+}
+
+// --- public ---
+
+// Returns the target point in world coordinates.
+func (self *MouseJoint) GetTarget() Vec3 {
+	return self.target
+}
+
+// Sets `target` to the target point in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *MouseJoint) GetTargetTo(target *Vec3) {
+	*target = self.target
+}
+
+// Sets the target point in world coordinates to `target`, and wakes up the dragged rigid body.
+func (self *MouseJoint) SetTarget(target Vec3) {
+	self.target = target
+	self.b2.WakeUp()
+}
+
+// Returns the spring and damper settings.
+func (self *MouseJoint) GetSpringDamper() *SpringDamper {
+	return self.sd
+}
+
+// Returns the maximum force of the pull. `0` means no limit.
+func (self *MouseJoint) GetMaxForce() float64 {
+	return self.maxForce
+}
+
+// Sets the maximum force of the pull to `maxForce`, and wakes up the dragged rigid body. Set `0` for no limit.
+func (self *MouseJoint) SetMaxForce(maxForce float64) {
+	self.maxForce = maxForce
+	self.b2.WakeUp()
+}
@@ -0,0 +1,34 @@
+package demos
+
+//////////////////////////////////////////////// MouseJointConfig
+// (goimo)
+// A mouse joint config is used for constructions of mouse joints.
+
+type MouseJointConfig struct {
+	*JointConfig
+
+	// The target point in world coordinates the anchor point is pulled toward.
+	Target Vec3
+
+	// The spring and damper that pulls the anchor point. Set the frequency to `0` to pull it rigidly.
+	SpringDamper *SpringDamper
+
+	// The maximum force of the pull in any direction. Set `0` for no limit.
+	MaxForce float64
+}
+
+func NewMouseJointConfig() *MouseJointConfig {
+	return &MouseJointConfig{
+		JointConfig:  NewJointConfig(),
+		SpringDamper: NewSpringDamper().SetSpring(5, 0.7),
+		MaxForce:     0,
+	}
+}
+
+// Sets rigid bodies, the local anchor of `rigidBody` and the target from the world anchor `worldAnchor`, and returns
+// `this`. `ground` is a static or kinematic rigid body the joint is attached to, it is not affected by the joint.
+func (self *MouseJointConfig) Init(ground, rigidBody *RigidBody, worldAnchor Vec3) *MouseJointConfig {
+	self.init(ground, rigidBody, worldAnchor)
+	self.Target = worldAnchor
+	return self
+}
@@ -0,0 +1,50 @@
+package demos
+
+//////////////////////////////////////////////// MouseJointConstraintSolver
+// (goimo)
+// A constraint solver of mouse joints. The rows along the world axes are solved with projected Gauss-Seidel as usual,
+// then their impulses are clamped together as a vector, so that the pull is limited by the maximum force in every
+// direction rather than along each axis.
+
+type MouseJointConstraintSolver struct {
+	*PgsJointConstraintSolver
+
+	joint *MouseJoint
+}
+
+func NewMouseJointConstraintSolver(joint *MouseJoint) *MouseJointConstraintSolver {
+	return &MouseJointConstraintSolver{
+		PgsJointConstraintSolver: NewPgsJointConstraintSolver(joint.Joint),
+
+		joint: joint,
+	}
+}
+
+func (self *MouseJointConstraintSolver) SolveVelocity() { // override
+	self.PgsJointConstraintSolver.SolveVelocity()
+
+	// clamp the length of the impulse
+	rows := self.info.rows
+	impulse := Vec3{rows[0].impulse.impulse, rows[1].impulse.impulse, rows[2].impulse.impulse}
+	maxImpulse := self.joint.maxImpulse
+	lenSq := impulse.LengthSq()
+	if lenSq <= maxImpulse*maxImpulse {
+		return
+	}
+	scale := maxImpulse / MathUtil.Sqrt(lenSq)
+
+	lv1 := self.b1.vel
+	lv2 := self.b2.vel
+	av1 := self.b1.angVel
+	av2 := self.b2.angVel
+
+	for i := range 3 {
+		imp := rows[i].impulse
+		oldImpulse := imp.impulse
+		imp.impulse *= scale
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, rows[i].jacobian, self.massData[i], imp.impulse-oldImpulse)
+	}
+
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
+}
@@ -0,0 +1,57 @@
+package demos
+
+import (
+	"testing"
+)
+
+func TestMouseJointPull(t *testing.T) {
+	w := groundTestWorld()
+	ground := w.GetRigidBodyList()
+	rc := NewRigidBodyConfig()
+	rc.Position = Vec3{0, 3, 0}
+	box := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.25, 0.25, 0.25})
+	box.AddShape(NewShape(sc))
+	w.AddRigidBody(box)
+
+	j := NewMouseJoint(NewMouseJointConfig().Init(ground, box, Vec3{0, 3, 0}))
+	w.AddJoint(j.Joint)
+	j.SetTarget(Vec3{2, 4, 0})
+	stepWorld(w, 180)
+	if d := box.GetPosition(); d.SubEq(Vec3{2, 4, 0}).Length() > 0.05 {
+		t.Fatalf("the box is off the target by %v", d)
+	}
+
+	// a pull weaker than the weight of the box of about 1.2 N can't hold it up
+	j.SetMaxForce(0.5)
+	stepWorld(w, 30)
+	if y := box.GetPosition().y; y > 3.5 {
+		t.Fatalf("the box is held at %v", y)
+	}
+}
+
+func TestMouseJointMaxForceDiagonal(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, &Vec3{})
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	ground := NewRigidBody(rc)
+	w.AddRigidBody(ground)
+	rc = NewRigidBodyConfig()
+	box := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.25, 0.25, 0.25})
+	box.AddShape(NewShape(sc))
+	w.AddRigidBody(box)
+
+	// a diagonal pull is limited to the maximum force in length, not along each axis
+	mc := NewMouseJointConfig().Init(ground, box, Vec3{})
+	mc.MaxForce = 1
+	j := NewMouseJoint(mc)
+	w.AddJoint(j.Joint)
+	j.SetTarget(Vec3{10, 10, 10})
+	w.Step(1.0 / 60)
+	if dv := box.GetLinearVelocity(); dv.Length() > 1.0/60/box.GetMass()+1e-9 {
+		t.Fatalf("the pull changed the velocity by %v", dv)
+	}
+}
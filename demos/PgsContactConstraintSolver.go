@@ -30,11 +30,12 @@ func (self *PgsContactConstraintSolver) _updatePositionData() {
 	self.constraint.syncManifold()
 	self.constraint.getPositionSolverInfo(self.info)
 
-	invM1 := self.b1.invMass
-	invM2 := self.b2.invMass
+	scale1, scale2 := self.constraint.GetInvMassScales()
+	invM1 := self.b1.invMass * scale1
+	invM2 := self.b2.invMass * scale2
 
-	invI1 := self.b1.invInertia
-	invI2 := self.b2.invInertia
+	invI1 := self.b1.invInertia.Scale(scale1)
+	invI2 := self.b2.invInertia.Scale(scale2)
 
 	// compute mass data
 	for i := range self.info.numRows {
@@ -55,16 +56,21 @@ func (self *PgsContactConstraintSolver) _updatePositionData() {
 }
 
 func (self *PgsContactConstraintSolver) PreSolveVelocity(timeStep TimeStep) {
+	if timeStep.subStep > 0 {
+		// relax the contact by the positions of the last sub-step
+		self.constraint.syncManifold()
+	}
 	self.constraint.getVelocitySolverInfo(timeStep, self.info)
 
 	self.b1 = self.info.b1
 	self.b2 = self.info.b2
 
-	invM1 := self.b1.invMass
-	invM2 := self.b2.invMass
+	scale1, scale2 := self.constraint.GetInvMassScales()
+	invM1 := self.b1.invMass * scale1
+	invM2 := self.b2.invMass * scale2
 
-	invI1 := self.b1.invInertia
-	invI2 := self.b2.invInertia
+	invI1 := self.b1.invInertia.Scale(scale1)
+	invI2 := self.b2.invInertia.Scale(scale2)
 
 	// compute mass data
 	for i := range self.info.numRows {
@@ -78,7 +84,7 @@ func (self *PgsContactConstraintSolver) PreSolveVelocity(timeStep TimeStep) {
 		md.invMAngN1 = j.ang1.MulMat3(&invI1)
 		md.invMAngN2 = j.ang2.MulMat3(&invI2)
 
-		md.massN = invM1 + invM2 + md.invMAngN1.Dot(j.ang1) + md.invMAngN2.Dot(j.ang2)
+		md.massN = invM1 + invM2 + md.invMAngN1.Dot(j.ang1) + md.invMAngN2.Dot(j.ang2) + row.cfm
 		if md.massN != 0 {
 			md.massN = 1.0 / md.massN
 		}
@@ -110,6 +116,38 @@ func (self *PgsContactConstraintSolver) PreSolveVelocity(timeStep TimeStep) {
 		md.massTB01 = -invMassTB01 * invDet
 		md.massTB10 = -invMassTB10 * invDet
 		md.massTB11 = invMassTB00 * invDet
+
+		// rolling/torsional mass, the angular rows use the tangent, the binormal and the normal as axes
+		if row.rollingFriction > 0 || row.torsionalFriction > 0 {
+			t := jt.lin1
+			b := jb.lin1
+			n := j.lin1
+			md.invMAngRT1 = t.MulMat3(&invI1)
+			md.invMAngRT2 = t.MulMat3(&invI2)
+			md.invMAngRB1 = b.MulMat3(&invI1)
+			md.invMAngRB2 = b.MulMat3(&invI2)
+			md.invMAngS1 = n.MulMat3(&invI1)
+			md.invMAngS2 = n.MulMat3(&invI2)
+
+			invMassR00 := md.invMAngRT1.Dot(t) + md.invMAngRT2.Dot(t)
+			invMassR01 := md.invMAngRT1.Dot(b) + md.invMAngRT2.Dot(b)
+			invMassR11 := md.invMAngRB1.Dot(b) + md.invMAngRB2.Dot(b)
+
+			invDet = invMassR00*invMassR11 - invMassR01*invMassR01
+			if invDet != 0 {
+				invDet = 1.0 / invDet
+			}
+
+			md.massR00 = invMassR11 * invDet
+			md.massR01 = -invMassR01 * invDet
+			md.massR10 = md.massR01
+			md.massR11 = invMassR00 * invDet
+
+			md.massS = md.invMAngS1.Dot(n) + md.invMAngS2.Dot(n)
+			if md.massS != 0 {
+				md.massS = 1.0 / md.massS
+			}
+		}
 	}
 }
 
@@ -126,11 +164,15 @@ func (self *PgsContactConstraintSolver) WarmStart(timeStep TimeStep) {
 		jt := row.jacobianT
 		jb := row.jacobianB
 
+		// project the lateral impulse of the last step onto the new tangent and binormal, the later sub-steps keep
+		// the impulses of the last sub-step as the tangent and the binormal don't change within a step
 		impulseN := imp.impulseN
-		impulseT := imp.impulseL.Dot(jt.lin1)
-		impulseB := imp.impulseL.Dot(jb.lin1)
-		imp.impulseT = impulseT
-		imp.impulseB = impulseB
+		if timeStep.subStep == 0 {
+			imp.impulseT = imp.impulseL.Dot(jt.lin1)
+			imp.impulseB = imp.impulseL.Dot(jb.lin1)
+		}
+		impulseT := imp.impulseT
+		impulseB := imp.impulseB
 
 		// adjust impulse for variable time step
 		imp.impulseN *= timeStep.DtRatio
@@ -149,12 +191,39 @@ func (self *PgsContactConstraintSolver) WarmStart(timeStep TimeStep) {
 		av2 = av2.AddRhsScaled(md.invMAngN2, -impulseN)
 		av2 = av2.AddRhsScaled(md.invMAngT2, -impulseT)
 		av2 = av2.AddRhsScaled(md.invMAngB2, -impulseB)
+
+		// rolling/torsional impulses
+		if row.rollingFriction > 0 {
+			if timeStep.subStep == 0 {
+				imp.impulseRT = imp.impulseR.Dot(jt.lin1)
+				imp.impulseRB = imp.impulseR.Dot(jb.lin1)
+			}
+			impulseRT := imp.impulseRT
+			impulseRB := imp.impulseRB
+			imp.impulseRT *= timeStep.DtRatio
+			imp.impulseRB *= timeStep.DtRatio
+
+			av1 = av1.AddRhsScaled(md.invMAngRT1, impulseRT)
+			av1 = av1.AddRhsScaled(md.invMAngRB1, impulseRB)
+			av2 = av2.AddRhsScaled(md.invMAngRT2, -impulseRT)
+			av2 = av2.AddRhsScaled(md.invMAngRB2, -impulseRB)
+		} else {
+			imp.impulseRT = 0
+			imp.impulseRB = 0
+		}
+		if row.torsionalFriction > 0 {
+			impulseS := imp.impulseS
+			imp.impulseS *= timeStep.DtRatio
+
+			av1 = av1.AddRhsScaled(md.invMAngS1, impulseS)
+			av2 = av2.AddRhsScaled(md.invMAngS2, -impulseS)
+		} else {
+			imp.impulseS = 0
+		}
 	}
 
-	self.b1.vel = lv1
-	self.b2.vel = lv2
-	self.b1.angVel = av1
-	self.b2.angVel = av2
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
 }
 
 func (self *PgsContactConstraintSolver) SolveVelocity() {
@@ -184,6 +253,9 @@ func (self *PgsContactConstraintSolver) SolveVelocity() {
 		rvb += av1.Dot(j.ang1)
 		rvb -= av2.Dot(j.ang2)
 
+		rvt -= row.rhsT
+		rvb -= row.rhsB
+
 		impulseT := -(rvt*md.massTB00 + rvb*md.massTB01)
 		impulseB := -(rvt*md.massTB10 + rvb*md.massTB11)
 		oldImpulseT := imp.impulseT
@@ -191,15 +263,16 @@ func (self *PgsContactConstraintSolver) SolveVelocity() {
 		imp.impulseT += impulseT
 		imp.impulseB += impulseB
 
-		// cone friction
-		maxImpulse := row.friction * imp.impulseN
-		if maxImpulse == 0 {
-			imp.impulseT = 0
-			imp.impulseB = 0
+		// cone friction, or box friction if anisotropic
+		maxImpulseT := row.friction * row.frictionScaleT * imp.impulseN
+		maxImpulseB := row.friction * row.frictionScaleB * imp.impulseN
+		if maxImpulseT != maxImpulseB || maxImpulseT == 0 {
+			imp.impulseT = MathUtil.Clamp(imp.impulseT, -maxImpulseT, maxImpulseT)
+			imp.impulseB = MathUtil.Clamp(imp.impulseB, -maxImpulseB, maxImpulseB)
 		} else {
 			impulseLengthSq := imp.impulseT*imp.impulseT + imp.impulseB*imp.impulseB
-			if impulseLengthSq > maxImpulse*maxImpulse {
-				invL := maxImpulse / MathUtil.Sqrt(impulseLengthSq)
+			if impulseLengthSq > maxImpulseT*maxImpulseT {
+				invL := maxImpulseT / MathUtil.Sqrt(impulseLengthSq)
 				imp.impulseT *= invL
 				imp.impulseB *= invL
 			}
@@ -219,6 +292,64 @@ func (self *PgsContactConstraintSolver) SolveVelocity() {
 		av2 = av2.AddRhsScaled(md.invMAngB2, -impulseB)
 	}
 
+	// solve rolling/torsional friction
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+		imp := row.impulse
+
+		if row.rollingFriction > 0 {
+			// measure relative angular velocity
+			t := row.jacobianT.lin1
+			b := row.jacobianB.lin1
+			rwt := av1.Dot(t) - av2.Dot(t)
+			rwb := av1.Dot(b) - av2.Dot(b)
+
+			impulseRT := -(rwt*md.massR00 + rwb*md.massR01)
+			impulseRB := -(rwt*md.massR10 + rwb*md.massR11)
+			oldImpulseRT := imp.impulseRT
+			oldImpulseRB := imp.impulseRB
+			imp.impulseRT += impulseRT
+			imp.impulseRB += impulseRB
+
+			// cone rolling resistance
+			maxImpulse := row.rollingFriction * imp.impulseN
+			impulseLengthSq := imp.impulseRT*imp.impulseRT + imp.impulseRB*imp.impulseRB
+			if impulseLengthSq > maxImpulse*maxImpulse {
+				invL := maxImpulse / MathUtil.Sqrt(impulseLengthSq)
+				imp.impulseRT *= invL
+				imp.impulseRB *= invL
+			}
+
+			impulseRT = imp.impulseRT - oldImpulseRT
+			impulseRB = imp.impulseRB - oldImpulseRB
+
+			// apply delta impulse
+			av1 = av1.AddRhsScaled(md.invMAngRT1, impulseRT)
+			av1 = av1.AddRhsScaled(md.invMAngRB1, impulseRB)
+			av2 = av2.AddRhsScaled(md.invMAngRT2, -impulseRT)
+			av2 = av2.AddRhsScaled(md.invMAngRB2, -impulseRB)
+		}
+
+		if row.torsionalFriction > 0 {
+			// measure relative angular velocity
+			n := row.jacobianN.lin1
+			rws := av1.Dot(n) - av2.Dot(n)
+
+			impulseS := -rws * md.massS
+
+			// clamp impulse
+			maxImpulse := row.torsionalFriction * imp.impulseN
+			oldImpulseS := imp.impulseS
+			imp.impulseS = MathUtil.Clamp(imp.impulseS+impulseS, -maxImpulse, maxImpulse)
+			impulseS = imp.impulseS - oldImpulseS
+
+			// apply delta impulse
+			av1 = av1.AddRhsScaled(md.invMAngS1, impulseS)
+			av2 = av2.AddRhsScaled(md.invMAngS2, -impulseS)
+		}
+	}
+
 	// solve normal
 	for i := range self.info.numRows {
 		row := self.info.rows[i]
@@ -233,7 +364,7 @@ func (self *PgsContactConstraintSolver) SolveVelocity() {
 		rvn += av1.Dot(j.ang1)
 		rvn -= av2.Dot(j.ang2)
 
-		impulseN := (row.rhs - rvn) * md.massN
+		impulseN := (row.rhs - rvn - imp.impulseN*row.cfm) * md.massN
 
 		// clamp impulse
 		oldImpulseN := imp.impulseN
@@ -250,10 +381,8 @@ func (self *PgsContactConstraintSolver) SolveVelocity() {
 		av2 = av2.AddRhsScaled(md.invMAngN2, -impulseN)
 	}
 
-	self.b1.vel = lv1
-	self.b2.vel = lv2
-	self.b1.angVel = av1
-	self.b2.angVel = av2
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
 }
 
 func (self *PgsContactConstraintSolver) PostSolveVelocity(timeStep TimeStep) {
@@ -306,10 +435,8 @@ func (self *PgsContactConstraintSolver) SolvePositionSplitImpulse() {
 		av2 = av2.AddRhsScaled(md.invMAngN2, -impulseP)
 	}
 
-	self.b1.pseudoVel = lv1
-	self.b2.pseudoVel = lv2
-	self.b1.angPseudoVel = av1
-	self.b2.angPseudoVel = av2
+	self.b1.setSolvedPseudoVelocity(lv1, av1)
+	self.b2.setSolvedPseudoVelocity(lv2, av2)
 }
 
 func (self *PgsContactConstraintSolver) SolvePositionNgs(timeStep TimeStep) {
@@ -347,10 +474,8 @@ func (self *PgsContactConstraintSolver) SolvePositionNgs(timeStep TimeStep) {
 		av2 = av2.AddRhsScaled(md.invMAngN2, -impulseP)
 	}
 
-	self.b1.applyTranslation(lv1)
-	self.b2.applyTranslation(lv2)
-	self.b1.applyRotation(av1)
-	self.b2.applyRotation(av2)
+	self.b1.applySolvedDisplacement(lv1, av1)
+	self.b2.applySolvedDisplacement(lv2, av2)
 }
 
 func (self *PgsContactConstraintSolver) PostSolve() {
@@ -373,6 +498,17 @@ func (self *PgsContactConstraintSolver) PostSolve() {
 		impulseL = impulseL.AddRhsScaled(jb.lin1, impB)
 		imp.impulseL = impulseL
 
+		// store rolling impulse
+		var impulseR Vec3
+		impulseR = impulseR.AddRhsScaled(jt.lin1, imp.impulseRT)
+		impulseR = impulseR.AddRhsScaled(jb.lin1, imp.impulseRB)
+		imp.impulseR = impulseR
+
+		// the point is sliding if the friction could not stop the relative tangential motion
+		rvt := self.b1.vel.Dot(jt.lin1) - self.b2.vel.Dot(jt.lin2) + self.b1.angVel.Dot(jt.ang1) - self.b2.angVel.Dot(jt.ang2) - row.rhsT
+		rvb := self.b1.vel.Dot(jb.lin1) - self.b2.vel.Dot(jb.lin2) + self.b1.angVel.Dot(jb.ang1) - self.b2.angVel.Dot(jb.ang2) - row.rhsB
+		imp.sliding = rvt*rvt+rvb*rvb > Settings.ContactSlidingVelocityThreshold*Settings.ContactSlidingVelocityThreshold
+
 		// accumulate contact impulses
 		lin1 = lin1.AddRhsScaled(jn.lin1, impN)
 		ang1 = ang1.AddRhsScaled(jn.ang1, impN)
@@ -383,6 +519,10 @@ func (self *PgsContactConstraintSolver) PostSolve() {
 		lin1 = lin1.AddRhsScaled(jb.lin1, impB)
 		ang1 = ang1.AddRhsScaled(jb.ang1, impB)
 		ang2 = ang2.AddRhsScaled(jb.ang2, impB)
+		ang1 = ang1.AddRhsScaled(jn.lin1, imp.impulseS)
+		ang2 = ang2.AddRhsScaled(jn.lin2, imp.impulseS)
+		ang1.AddEq(impulseR)
+		ang2.AddEq(impulseR)
 	}
 
 	self.b1.linearContactImpulse = self.b1.linearContactImpulse.Add(lin1)
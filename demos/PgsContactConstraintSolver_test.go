@@ -0,0 +1,53 @@
+package demos
+
+import (
+	"testing"
+)
+
+func TestRollingAndTorsionalFriction(t *testing.T) {
+	w := groundTestWorld()
+	groundMaterial := w.GetRigidBodyList().GetShapeList().GetMaterial()
+	free := NewMaterial()
+	resistant := NewMaterial()
+	w.SetMaterialPair(groundMaterial, free, NewMaterialPair().Init(0, 0, 0))
+	w.SetMaterialPair(groundMaterial, resistant, NewMaterialPair().Init(0, 0, 0).SetRollingAndTorsionalFriction(10, 0.2))
+	box := func(z float64, onEdge bool, material *Material) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{0, 1, z}
+		if onEdge {
+			rc.Position.y = 0.5 + MathUtil.Sqrt(0.5)
+			MathUtil.Mat3_fromEulerXyz(&rc.Rotation, &Vec3{0, 0, MathUtil.PI / 4})
+		}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		sc.Material = material
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+
+	// boxes balanced on an edge are pushed to roll over, and boxes lying on a face spin, all without friction
+	rolling := []*RigidBody{box(-6, true, free), box(-3, true, resistant)}
+	spinning := []*RigidBody{box(3, false, free), box(6, false, resistant)}
+	for _, rb := range rolling {
+		rb.SetAngularVelocity(Vec3{0, 0, -0.5})
+	}
+	for _, rb := range spinning {
+		rb.SetAngularVelocity(Vec3{0, 5, 0})
+	}
+
+	stepWorld(w, 120)
+	if y := rolling[0].GetPosition().y; y > 1.05 {
+		t.Fatalf("the free box didn't roll over: %v", y)
+	}
+	if y := rolling[1].GetPosition().y; y < 1.15 {
+		t.Fatalf("the resistant box rolled over: %v", y)
+	}
+	if av := spinning[0].GetAngularVelocity(); av.y < 4.9 {
+		t.Fatalf("the free box slowed its spin down to %v", av.y)
+	}
+	if av := spinning[1].GetAngularVelocity(); av.y > 0.05 {
+		t.Fatalf("the resistant box spins at %v", av.y)
+	}
+}
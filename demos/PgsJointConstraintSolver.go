@@ -28,4 +28,302 @@ func NewPgsJointConstraintSolver(joint *Joint) *PgsJointConstraintSolver {
 	return p
 }
 
-// TODO
+// computes impulse -> velocity change vectors of a row, returns the inverse mass without cfm
+func (self *PgsJointConstraintSolver) _computeMassDataRow(row *JointSolverInfoRow, md *JointSolverMassDataRow) float64 {
+	invM1 := self.b1.invMass
+	invM2 := self.b2.invMass
+
+	invI1 := self.b1.invInertia
+	invI2 := self.b2.invInertia
+
+	j := row.jacobian
+	md.invMLin1 = j.lin1.Scale(invM1)
+	md.invMLin2 = j.lin2.Scale(invM2)
+	md.invMAng1 = j.ang1.MulMat3(&invI1)
+	md.invMAng2 = j.ang2.MulMat3(&invI2)
+
+	return md.invMLin1.Dot(j.lin1) + md.invMLin2.Dot(j.lin2) + md.invMAng1.Dot(j.ang1) + md.invMAng2.Dot(j.ang2)
+}
+
+func (self *PgsJointConstraintSolver) _updatePositionData() {
+	self.joint.impl.syncAnchors()
+	self.joint.impl.getPositionSolverInfo(self.info)
+
+	self.b1 = self.info.b1
+	self.b2 = self.info.b2
+
+	// compute mass data
+	for i := range self.info.numRows {
+		md := self.massData[i]
+		md.mass = self._computeMassDataRow(self.info.rows[i], md)
+		if md.mass != 0 {
+			md.mass = 1.0 / md.mass
+		}
+	}
+}
+
+// applies `impulse` along the row to the given velocities
+func _applyJointImpulse(lv1, lv2, av1, av2 *Vec3, j *JacobianRow, md *JointSolverMassDataRow, impulse float64) {
+	if j.IsLinearSet() {
+		*lv1 = lv1.AddRhsScaled(md.invMLin1, impulse)
+		*lv2 = lv2.AddRhsScaled(md.invMLin2, -impulse)
+	}
+	if j.IsAngularSet() {
+		*av1 = av1.AddRhsScaled(md.invMAng1, impulse)
+		*av2 = av2.AddRhsScaled(md.invMAng2, -impulse)
+	}
+}
+
+// measures the relative velocity along the row
+func _measureJointRelVel(lv1, lv2, av1, av2 *Vec3, j *JacobianRow) float64 {
+	rv := 0.0
+	if j.IsLinearSet() {
+		rv += lv1.Dot(j.lin1)
+		rv -= lv2.Dot(j.lin2)
+	}
+	if j.IsAngularSet() {
+		rv += av1.Dot(j.ang1)
+		rv -= av2.Dot(j.ang2)
+	}
+	return rv
+}
+
+func (self *PgsJointConstraintSolver) PreSolveVelocity(timeStep TimeStep) {
+	self.joint.impl.syncAnchors()
+	self.joint.impl.getVelocitySolverInfo(timeStep, self.info)
+
+	self.b1 = self.info.b1
+	self.b2 = self.info.b2
+
+	// compute mass data
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+
+		md.massWithoutCfm = self._computeMassDataRow(row, md)
+		md.mass = md.massWithoutCfm + row.cfm
+		md.motorMass = md.massWithoutCfm + row.motorCfm
+
+		if md.mass != 0 {
+			md.mass = 1.0 / md.mass
+		}
+		if md.motorMass != 0 {
+			md.motorMass = 1.0 / md.motorMass
+		}
+		if md.massWithoutCfm != 0 {
+			md.massWithoutCfm = 1.0 / md.massWithoutCfm
+		}
+	}
+}
+
+func (self *PgsJointConstraintSolver) WarmStart(timeStep TimeStep) {
+	var factor float64
+	if self.joint.positionCorrectionAlgorithm == PositionCorrectionAlgorithm_BAUMGARTE {
+		factor = Settings.JointWarmStartingFactorForBaungarte
+	} else {
+		factor = Settings.JointWarmStartingFactor
+	}
+
+	// adjust impulse for variable time step
+	factor *= timeStep.DtRatio
+
+	// warm starting disabled
+	if factor <= 0 {
+		for i := range self.info.numRows {
+			self.info.rows[i].impulse.Clear()
+		}
+		return
+	}
+
+	lv1 := self.b1.vel
+	lv2 := self.b2.vel
+	av1 := self.b1.angVel
+	av2 := self.b2.angVel
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		imp := row.impulse
+		md := self.massData[i]
+
+		// update limit impulse
+		imp.impulse *= factor
+
+		// update motor impulse
+		imp.impulseM *= factor
+
+		impulse := imp.impulse + imp.impulseM
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, row.jacobian, md, impulse)
+	}
+
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
+}
+
+func (self *PgsJointConstraintSolver) SolveVelocity() {
+	lv1 := self.b1.vel
+	lv2 := self.b2.vel
+	av1 := self.b1.angVel
+	av2 := self.b2.angVel
+
+	// solve motor
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+		imp := row.impulse
+		j := row.jacobian
+
+		if row.motorMaxImpulse == 0 {
+			continue
+		}
+
+		// measure relative velocity
+		rv := _measureJointRelVel(&lv1, &lv2, &av1, &av2, j)
+
+		impulseM := (-row.motorSpeed - rv - imp.impulseM*row.motorCfm) * md.motorMass
+
+		// clamp impulse
+		oldImpulseM := imp.impulseM
+		imp.impulseM += impulseM
+		if imp.impulseM < -row.motorMaxImpulse {
+			imp.impulseM = -row.motorMaxImpulse
+		} else if imp.impulseM > row.motorMaxImpulse {
+			imp.impulseM = row.motorMaxImpulse
+		}
+		impulseM = imp.impulseM - oldImpulseM
+
+		// apply delta impulse
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, j, md, impulseM)
+	}
+
+	// solve normal
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+		imp := row.impulse
+		j := row.jacobian
+
+		// measure relative velocity
+		rv := _measureJointRelVel(&lv1, &lv2, &av1, &av2, j)
+
+		impulse := (row.rhs - rv - imp.impulse*row.cfm) * md.mass
+
+		// clamp impulse
+		oldImpulse := imp.impulse
+		imp.impulse += impulse
+		if imp.impulse < row.minImpulse {
+			imp.impulse = row.minImpulse
+		} else if imp.impulse > row.maxImpulse {
+			imp.impulse = row.maxImpulse
+		}
+		impulse = imp.impulse - oldImpulse
+
+		// apply delta impulse
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, j, md, impulse)
+	}
+
+	self.b1.setSolvedVelocity(lv1, av1)
+	self.b2.setSolvedVelocity(lv2, av2)
+}
+
+func (self *PgsJointConstraintSolver) PostSolveVelocity(timeStep TimeStep) {
+	// compute total linear and angular impulse
+	var lin, ang Vec3
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		imp := row.impulse
+		j := row.jacobian
+		if j.IsLinearSet() {
+			lin = lin.AddRhsScaled(j.lin1, imp.impulse)
+		}
+		if j.IsAngularSet() {
+			ang = ang.AddRhsScaled(j.ang1, imp.impulse)
+		}
+	}
+
+	self.joint.appliedForce = lin.Scale(timeStep.InvDt)
+	self.joint.appliedTorque = ang.Scale(timeStep.InvDt)
+}
+
+func (self *PgsJointConstraintSolver) PreSolvePosition(timeStep TimeStep) {
+	self._updatePositionData()
+
+	// clear position impulses
+	for i := range self.info.numRows {
+		self.info.rows[i].impulse.impulseP = 0
+	}
+}
+
+func (self *PgsJointConstraintSolver) SolvePositionSplitImpulse() {
+	lv1 := self.b1.pseudoVel
+	lv2 := self.b2.pseudoVel
+	av1 := self.b1.angPseudoVel
+	av2 := self.b2.angPseudoVel
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+		imp := row.impulse
+		j := row.jacobian
+
+		// measure relative velocity
+		rv := _measureJointRelVel(&lv1, &lv2, &av1, &av2, j)
+
+		impulseP := (row.rhs*Settings.PositionSplitImpulseBaumgarte - rv) * md.mass
+
+		// clamp impulse
+		oldImpulseP := imp.impulseP
+		imp.impulseP += impulseP
+		if imp.impulseP < row.minImpulse {
+			imp.impulseP = row.minImpulse
+		} else if imp.impulseP > row.maxImpulse {
+			imp.impulseP = row.maxImpulse
+		}
+		impulseP = imp.impulseP - oldImpulseP
+
+		// apply delta impulse
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, j, md, impulseP)
+	}
+
+	self.b1.setSolvedPseudoVelocity(lv1, av1)
+	self.b2.setSolvedPseudoVelocity(lv2, av2)
+}
+
+func (self *PgsJointConstraintSolver) SolvePositionNgs(timeStep TimeStep) {
+	self._updatePositionData()
+
+	var lv1, lv2, av1, av2 Vec3
+
+	for i := range self.info.numRows {
+		row := self.info.rows[i]
+		md := self.massData[i]
+		imp := row.impulse
+		j := row.jacobian
+
+		// estimate translation
+		rv := _measureJointRelVel(&lv1, &lv2, &av1, &av2, j)
+
+		impulseP := (row.rhs*Settings.PositionNgsBaumgarte - rv) * md.mass
+
+		// clamp impulse
+		oldImpulseP := imp.impulseP
+		imp.impulseP += impulseP
+		if imp.impulseP < row.minImpulse {
+			imp.impulseP = row.minImpulse
+		} else if imp.impulseP > row.maxImpulse {
+			imp.impulseP = row.maxImpulse
+		}
+		impulseP = imp.impulseP - oldImpulseP
+
+		// apply delta impulse
+		_applyJointImpulse(&lv1, &lv2, &av1, &av2, j, md, impulseP)
+	}
+
+	self.b1.applySolvedDisplacement(lv1, av1)
+	self.b2.applySolvedDisplacement(lv2, av2)
+}
+
+func (self *PgsJointConstraintSolver) PostSolve() {
+	self.joint.impl.syncAnchors()
+	self.joint.checkDestruction()
+}
@@ -0,0 +1,173 @@
+package demos
+
+//////////////////////////////////////////////// PrismaticJoint
+// (oimo/dynamics/constraint/joint/PrismaticJoint.go)
+// A prismatic joint (a.k.a. slider joint) constrains two rigid bodies to share their anchor points and constraint axes,
+// and restricts relative translation onto the constraint axis. This joint provides one degree of freedom. You can enable
+// lower and upper limits, a motor, a spring and damper effect of the translational part of the constraint.
+
+type PrismaticJoint struct {
+	*Joint
+
+	sd *SpringDamper
+	lm *TranslationalLimitMotor
+
+	translation  float64
+	linearErrorY float64
+	linearErrorZ float64
+	angularError Vec3
+}
+
+// Creates a new prismatic joint by configuration `config`.
+func NewPrismaticJoint(config *PrismaticJointConfig) *PrismaticJoint {
+	j := &PrismaticJoint{
+		Joint: NewJoint(config.JointConfig, JointType_PRISMATIC),
+		sd:    config.SpringDamper.Clone(),
+		lm:    config.LimitMotor.Clone(),
+	}
+	j.impl = j
+
+	j.localBasisX1 = config.LocalAxis1
+	j.localBasisX2 = config.LocalAxis2
+	j.buildLocalBasesFromX()
+
+	return j
+}
+
+func (self *PrismaticJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	// compute rhs
+	linRhsY := self.linearErrorY * erp
+	linRhsZ := self.linearErrorZ * erp
+	angRhs := self.angularError.Scale(erp)
+
+	motorMass := self.b1.invMass + self.b2.invMass
+	if motorMass != 0 {
+		motorMass = 1 / motorMass
+	}
+
+	// linear X
+	if self.sd.Frequency <= 0 || !isPositionPart {
+		row := info.AddRow(&self.impulses[0])
+		self.setSolverInfoRowLinear(row, self.translation, self.lm, motorMass, self.sd, timeStep, isPositionPart)
+		self.setJacobianLinear(row.jacobian, self.basisX1)
+	}
+
+	// linear Y
+	row := info.AddRow(&self.impulses[1])
+	row.EqualLimit(linRhsY, 0)
+	self.setJacobianLinear(row.jacobian, self.basisY1)
+
+	// linear Z
+	row = info.AddRow(&self.impulses[2])
+	row.EqualLimit(linRhsZ, 0)
+	self.setJacobianLinear(row.jacobian, self.basisZ1)
+
+	// angular X
+	row = info.AddRow(&self.impulses[3])
+	row.EqualLimit(angRhs.x, 0)
+	self.setJacobianAngular(row.jacobian, Vec3{1, 0, 0})
+
+	// angular Y
+	row = info.AddRow(&self.impulses[4])
+	row.EqualLimit(angRhs.y, 0)
+	self.setJacobianAngular(row.jacobian, Vec3{0, 1, 0})
+
+	// angular Z
+	row = info.AddRow(&self.impulses[5])
+	row.EqualLimit(angRhs.z, 0)
+	self.setJacobianAngular(row.jacobian, Vec3{0, 0, 1})
+}
+
+func (self *PrismaticJoint) computeErrors() {
+	anchorDiff := self.anchor2.Sub(self.anchor1)
+	self.translation = anchorDiff.Dot(self.basisX1)
+	self.linearErrorY = anchorDiff.Dot(self.basisY1)
+	self.linearErrorZ = anchorDiff.Dot(self.basisZ1)
+	self.angularError = self.computeAngularError()
+}
+
+// --- internal ---
+
+func (self *PrismaticJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *PrismaticJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *PrismaticJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first rigid body's constraint axis in world coordinates.
+func (self *PrismaticJoint) GetAxis1() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second rigid body's constraint axis in world coordinates.
+func (self *PrismaticJoint) GetAxis2() Vec3 {
+	return self.basisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *PrismaticJoint) GetAxis1To(axis *Vec3) {
+	*axis = self.basisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *PrismaticJoint) GetAxis2To(axis *Vec3) {
+	*axis = self.basisX2
+}
+
+// Returns the first rigid body's constraint axis relative to the rigid body's transform.
+func (self *PrismaticJoint) GetLocalAxis1() Vec3 {
+	return self.localBasisX1
+}
+
+// Returns the second rigid body's constraint axis relative to the rigid body's transform.
+func (self *PrismaticJoint) GetLocalAxis2() Vec3 {
+	return self.localBasisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *PrismaticJoint) GetLocalAxis1To(axis *Vec3) {
+	*axis = self.localBasisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *PrismaticJoint) GetLocalAxis2To(axis *Vec3) {
+	*axis = self.localBasisX2
+}
+
+// Returns the translational spring and damper settings.
+func (self *PrismaticJoint) GetSpringDamper() *SpringDamper {
+	return self.sd
+}
+
+// Returns the translational limits and motor settings.
+func (self *PrismaticJoint) GetLimitMotor() *TranslationalLimitMotor {
+	return self.lm
+}
+
+// Returns the translation of the joint.
+func (self *PrismaticJoint) GetTranslation() float64 {
+	return self.translation
+}
+
+// Returns the speed of the translation along the constraint axis, in meters per second.
+func (self *PrismaticJoint) GetTranslationalSpeed() float64 {
+	return self.computeTranslationalSpeed(self.basisX1)
+}
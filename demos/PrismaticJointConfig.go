@@ -0,0 +1,39 @@
+package demos
+
+//////////////////////////////////////////////// PrismaticJointConfig
+// (oimo/dynamics/constraint/joint/PrismaticJointConfig.go)
+// A prismatic joint config is used for constructions of prismatic joints.
+
+type PrismaticJointConfig struct {
+	*JointConfig
+
+	// The first body's local constraint axis.
+	LocalAxis1 Vec3
+
+	// The second body's local constraint axis.
+	LocalAxis2 Vec3
+
+	// The translational limit and motor along the constraint axis of the joint.
+	LimitMotor *TranslationalLimitMotor
+
+	// The translational spring and damper along the constraint axis of the joint.
+	SpringDamper *SpringDamper
+}
+
+func NewPrismaticJointConfig() *PrismaticJointConfig {
+	return &PrismaticJointConfig{
+		JointConfig:  NewJointConfig(),
+		LocalAxis1:   Vec3{1, 0, 0},
+		LocalAxis2:   Vec3{1, 0, 0},
+		LimitMotor:   NewTranslationalLimitMotor(),
+		SpringDamper: NewSpringDamper(),
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local axes from the world axis `worldAxis`, and returns `this`.
+func (self *PrismaticJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor, worldAxis Vec3) *PrismaticJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+	rigidBody1.GetLocalVectorTo(worldAxis, &self.LocalAxis1)
+	rigidBody2.GetLocalVectorTo(worldAxis, &self.LocalAxis2)
+	return self
+}
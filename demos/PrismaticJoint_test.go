@@ -0,0 +1,32 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrismaticJointLimitsAndMotor(t *testing.T) {
+	w, base, box := jointTestWorld()
+	config := NewPrismaticJointConfig().Init(base, box, Vec3{}, Vec3{1, 0, 0})
+	config.LimitMotor.SetLimits(-0.5, 0.5).SetMotor(1, 100)
+	j := NewPrismaticJoint(config)
+	w.AddJoint(j.Joint)
+
+	stepWorld(w, 10)
+	if s := j.GetTranslationalSpeed(); math.Abs(s-1) > 1e-3 {
+		t.Fatalf("the motor runs at %v", s)
+	}
+	// a limit only engages once crossed, so the joint may overshoot it by a step at the motor speed
+	for range 60 {
+		stepWorld(w, 1)
+		if j.GetTranslation() > 0.5+1.0/60+Settings.LinearSlop {
+			t.Fatalf("translated past the upper limit: %v", j.GetTranslation())
+		}
+	}
+	if tr, s := j.GetTranslation(), j.GetTranslationalSpeed(); math.Abs(tr-0.5) > Settings.LinearSlop*2 || math.Abs(s) > 1e-3 {
+		t.Fatalf("stopped at %v with speed %v", tr, s)
+	}
+	if p := box.GetPosition(); math.Abs(p.y) > 1e-6 || math.Abs(p.z) > 1e-6 {
+		t.Fatalf("left the axis: %v", p)
+	}
+}
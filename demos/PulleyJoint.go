@@ -0,0 +1,120 @@
+package demos
+
+//////////////////////////////////////////////// PulleyJoint
+// (goimo)
+// A pulley joint connects two anchor points to two fixed ground anchors with a rope running over them, and keeps
+// `length1 + ratio * length2` constant, where the lengths are the distances between the anchor points and their
+// ground anchors. This joint provides five degrees of freedom.
+
+type PulleyJoint struct {
+	*Joint
+
+	groundAnchor1 Vec3
+	groundAnchor2 Vec3
+	ratio         float64
+
+	length1 float64
+	length2 float64
+
+	// the value of `length1 + ratio * length2` to keep
+	constant float64
+
+	// the unit vectors from the ground anchors to the anchor points
+	direction1 Vec3
+	direction2 Vec3
+}
+
+// Creates a new pulley joint by configuration `config`.
+func NewPulleyJoint(config *PulleyJointConfig) *PulleyJoint {
+	j := &PulleyJoint{
+		Joint:         NewJoint(config.JointConfig, JointType_PULLEY),
+		groundAnchor1: config.GroundAnchor1,
+		groundAnchor2: config.GroundAnchor2,
+		ratio:         config.Ratio,
+		direction1:    Vec3{0, -1, 0},
+		direction2:    Vec3{0, -1, 0},
+	}
+	j.impl = j
+
+	j.Joint.syncAnchors()
+	j.computeErrors()
+	j.constant = j.length1 + j.ratio*j.length2
+
+	return j
+}
+
+func (self *PulleyJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	err := self.length1 + self.ratio*self.length2 - self.constant
+
+	row := info.AddRow(&self.impulses[0])
+	row.EqualLimit(err*erp, 0)
+
+	j := row.jacobian
+	j.lin1 = self.direction1.Negate()
+	j.ang1 = self.relativeAnchor1.Cross(j.lin1)
+	j.lin2 = self.direction2.Scale(self.ratio)
+	j.ang2 = self.relativeAnchor2.Cross(j.lin2)
+	j._updateSparsity()
+}
+
+func (self *PulleyJoint) computeErrors() {
+	diff1 := self.anchor1.Sub(self.groundAnchor1)
+	diff2 := self.anchor2.Sub(self.groundAnchor2)
+	self.length1 = diff1.Length()
+	self.length2 = diff2.Length()
+
+	// keep the last directions if the anchors coincide
+	if self.length1 > 0 {
+		self.direction1 = diff1.Scale(1 / self.length1)
+	}
+	if self.length2 > 0 {
+		self.direction2 = diff2.Scale(1 / self.length2)
+	}
+}
+
+// --- internal ---
+
+func (self *PulleyJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *PulleyJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *PulleyJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first ground anchor in world coordinates.
+func (self *PulleyJoint) GetGroundAnchor1() Vec3 {
+	return self.groundAnchor1
+}
+
+// Returns the second ground anchor in world coordinates.
+func (self *PulleyJoint) GetGroundAnchor2() Vec3 {
+	return self.groundAnchor2
+}
+
+// Returns the pulley ratio.
+func (self *PulleyJoint) GetRatio() float64 {
+	return self.ratio
+}
+
+// Returns the distance between the first anchor point and the first ground anchor.
+func (self *PulleyJoint) GetLength1() float64 {
+	return self.length1
+}
+
+// Returns the distance between the second anchor point and the second ground anchor.
+func (self *PulleyJoint) GetLength2() float64 {
+	return self.length2
+}
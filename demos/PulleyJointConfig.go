@@ -0,0 +1,36 @@
+package demos
+
+//////////////////////////////////////////////// PulleyJointConfig
+// (goimo)
+// A pulley joint config is used for constructions of pulley joints.
+
+type PulleyJointConfig struct {
+	*JointConfig
+
+	// The fixed point in world coordinates the rope from the first anchor runs over.
+	GroundAnchor1 Vec3
+
+	// The fixed point in world coordinates the rope from the second anchor runs over.
+	GroundAnchor2 Vec3
+
+	// The pulley ratio. The joint keeps `length1 + ratio * length2` constant.
+	Ratio float64
+}
+
+func NewPulleyJointConfig() *PulleyJointConfig {
+	return &PulleyJointConfig{
+		JointConfig: NewJointConfig(),
+		Ratio:       1,
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchors `worldAnchor1` and `worldAnchor2`, ground anchors from
+// `groundAnchor1` and `groundAnchor2`, the pulley ratio `ratio`, and returns `this`.
+func (self *PulleyJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, groundAnchor1, groundAnchor2, worldAnchor1, worldAnchor2 Vec3, ratio float64) *PulleyJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor1)
+	rigidBody2.GetLocalPointTo(worldAnchor2, &self.LocalAnchor2)
+	self.GroundAnchor1 = groundAnchor1
+	self.GroundAnchor2 = groundAnchor2
+	self.Ratio = ratio
+	return self
+}
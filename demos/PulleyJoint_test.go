@@ -0,0 +1,35 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPulleyJointConstant(t *testing.T) {
+	w := groundTestWorld()
+	box := func(x, density float64) *RigidBody {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x, 3, 0}
+		rb := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.25, 0.25, 0.25})
+		sc.Density = density
+		rb.AddShape(NewShape(sc))
+		w.AddRigidBody(rb)
+		return rb
+	}
+	light := box(-1, 1)
+	heavy := box(1, 3)
+	j := NewPulleyJoint(NewPulleyJointConfig().Init(light, heavy, Vec3{-1, 6, 0}, Vec3{1, 6, 0}, Vec3{-1, 3, 0}, Vec3{1, 3, 0}, 2))
+	w.AddJoint(j.Joint)
+
+	// the heavy box outweighs twice the light one, so it goes down and the light one up twice as far
+	constant := j.GetLength1() + 2*j.GetLength2()
+	stepWorld(w, 30)
+	if err := j.GetLength1() + 2*j.GetLength2() - constant; math.Abs(err) > Settings.LinearSlop*2 {
+		t.Fatalf("the rope stretched by %v", err)
+	}
+	if dy := light.GetPosition().y - 3; dy < 0.1 || math.Abs(dy+2*(heavy.GetPosition().y-3)) > Settings.LinearSlop*2 {
+		t.Fatalf("the boxes are at %v and %v", light.GetPosition(), heavy.GetPosition())
+	}
+}
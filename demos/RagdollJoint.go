@@ -0,0 +1,209 @@
+package demos
+
+//////////////////////////////////////////////// RagdollJoint
+// (oimo/dynamics/constraint/joint/RagdollJoint.go)
+// A ragdoll joint is designed to simulate ragdoll's limbs. It constrains swing and twist angles between two rigid
+// bodies. The two rigid bodies have constraint axes, and the swing angle is defined by the angle of two constraint
+// axes, while the twist angle is defined by the rotation angle along the two axes. In addition to lower and upper
+// limits of the twist angle, You can set an "elliptic cone limit" of the swing angle by specifying two swing axes
+// (though one of them is automatically computed) and corresponding maximum swing angles. You can also enable a motor
+// of the twist part of the constraint, spring and damper effect of the both swing and twist part of the constraint.
+
+type RagdollJoint struct {
+	*Joint
+
+	twistSd *SpringDamper
+	twistLm *RotationalLimitMotor
+	swingSd *SpringDamper
+
+	maxSwingAngle1 float64
+	maxSwingAngle2 float64
+
+	swingAngle float64
+	twistAngle float64
+
+	// how far the swing rotation is out of the elliptic cone, negative if inside
+	swingError float64
+	swingAxis  Vec3
+	twistAxis  Vec3
+}
+
+// Creates a new ragdoll joint by configuration `config`.
+func NewRagdollJoint(config *RagdollJointConfig) *RagdollJoint {
+	j := &RagdollJoint{
+		Joint:   NewJoint(config.JointConfig, JointType_RAGDOLL),
+		twistSd: config.TwistSpringDamper.Clone(),
+		twistLm: config.TwistLimitMotor.Clone(),
+		swingSd: config.SwingSpringDamper.Clone(),
+	}
+	j.impl = j
+
+	j.SetMaxSwingAngle1(config.MaxSwingAngle1)
+	j.SetMaxSwingAngle2(config.MaxSwingAngle2)
+
+	j.localBasisX1 = config.LocalTwistAxis1
+	j.localBasisY1 = config.LocalSwingAxis1
+	j.localBasisX2 = config.LocalTwistAxis2
+	j.buildLocalBasesFromXY1X2()
+
+	return j
+}
+
+func (self *RagdollJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	// linear
+	self.addSphericalRows(info, erp)
+
+	// swing, only when out of the cone
+	if self.swingError > 0 && (self.swingSd.Frequency <= 0 || !isPositionPart) {
+		row := info.AddRow(&self.impulses[3])
+		swingMass := self.computeEffectiveInertiaMoment(self.swingAxis)
+		self.setSolverInfoRow(row, self.swingError, MathUtil.NEGATIVE_INFINITY, 0, 0, 0, Settings.AngularSlop, swingMass, self.swingSd, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.swingAxis)
+	}
+
+	// twist
+	if self.twistSd.Frequency <= 0 || !isPositionPart {
+		row := info.AddRow(&self.impulses[4])
+		twistMass := self.computeEffectiveInertiaMoment(self.twistAxis)
+		self.setSolverInfoRowAngular(row, self.twistAngle, self.twistLm, twistMass, self.twistSd, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.twistAxis)
+	}
+}
+
+func (self *RagdollJoint) computeErrors() {
+	var swing Vec3
+	swing, self.twistAngle = self.computeSwingTwist()
+
+	// the twist axis is the bisector of the two twist axes, which is perpendicular to the swing axis
+	self.twistAxis = self.basisX1.Add(self.basisX2)
+	if self.twistAxis.Dot(self.twistAxis) == 0 {
+		self.twistAxis = self.basisX2
+	} else {
+		self.twistAxis.Normalize()
+	}
+
+	self.swingAngle = swing.Length()
+	if self.swingAngle == 0 {
+		self.swingError = -1
+		return
+	}
+	self.swingAxis = swing.Scale(1 / self.swingAngle)
+
+	// the max swing angle in the swing direction, on the elliptic cone
+	cos := self.swingAxis.Dot(self.basisY1) / self.maxSwingAngle1
+	sin := self.swingAxis.Dot(self.basisZ1) / self.maxSwingAngle2
+	maxSwingAngle := 1 / MathUtil.Sqrt(cos*cos+sin*sin)
+
+	self.swingError = self.swingAngle - maxSwingAngle
+}
+
+// --- internal ---
+
+func (self *RagdollJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *RagdollJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *RagdollJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first rigid body's constraint axis in world coordinates.
+func (self *RagdollJoint) GetAxis1() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second rigid body's constraint axis in world coordinates.
+func (self *RagdollJoint) GetAxis2() Vec3 {
+	return self.basisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *RagdollJoint) GetAxis1To(axis *Vec3) {
+	*axis = self.basisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *RagdollJoint) GetAxis2To(axis *Vec3) {
+	*axis = self.basisX2
+}
+
+// Returns the first rigid body's constraint axis relative to the rigid body's transform.
+func (self *RagdollJoint) GetLocalAxis1() Vec3 {
+	return self.localBasisX1
+}
+
+// Returns the second rigid body's constraint axis relative to the rigid body's transform.
+func (self *RagdollJoint) GetLocalAxis2() Vec3 {
+	return self.localBasisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *RagdollJoint) GetLocalAxis1To(axis *Vec3) {
+	*axis = self.localBasisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *RagdollJoint) GetLocalAxis2To(axis *Vec3) {
+	*axis = self.localBasisX2
+}
+
+// Returns the rotational spring and damper settings along the twist axis.
+func (self *RagdollJoint) GetTwistSpringDamper() *SpringDamper {
+	return self.twistSd
+}
+
+// Returns the rotational limits and motor settings along the twist axis.
+func (self *RagdollJoint) GetTwistLimitMotor() *RotationalLimitMotor {
+	return self.twistLm
+}
+
+// Returns the rotational spring and damper settings along the swing axis.
+func (self *RagdollJoint) GetSwingSpringDamper() *SpringDamper {
+	return self.swingSd
+}
+
+// Returns the max swing angle along the first swing axis in radians.
+func (self *RagdollJoint) GetMaxSwingAngle1() float64 {
+	return self.maxSwingAngle1
+}
+
+// Returns the max swing angle along the second swing axis in radians.
+func (self *RagdollJoint) GetMaxSwingAngle2() float64 {
+	return self.maxSwingAngle2
+}
+
+// Sets the max swing angle along the first swing axis in radians.
+func (self *RagdollJoint) SetMaxSwingAngle1(maxSwingAngle1 float64) {
+	self.maxSwingAngle1 = max(maxSwingAngle1, Settings.MinRagdollMaxSwingAngle)
+}
+
+// Sets the max swing angle along the second swing axis in radians.
+func (self *RagdollJoint) SetMaxSwingAngle2(maxSwingAngle2 float64) {
+	self.maxSwingAngle2 = max(maxSwingAngle2, Settings.MinRagdollMaxSwingAngle)
+}
+
+// Returns the swing angle in radians.
+func (self *RagdollJoint) GetSwingAngle() float64 {
+	return self.swingAngle
+}
+
+// Returns the twist angle in radians.
+func (self *RagdollJoint) GetTwistAngle() float64 {
+	return self.twistAngle
+}
@@ -0,0 +1,61 @@
+package demos
+
+//////////////////////////////////////////////// RagdollJointConfig
+// (oimo/dynamics/constraint/joint/RagdollJointConfig.go)
+// A ragdoll joint config is used for constructions of ragdoll joints.
+
+type RagdollJointConfig struct {
+	*JointConfig
+
+	// The first body's local twist axis.
+	LocalTwistAxis1 Vec3
+
+	// The second body's local twist axis.
+	LocalTwistAxis2 Vec3
+
+	// The first body's local swing axis.
+	// The second swing axis is also attached to the first body. It is perpendicular to the first swing axis, and is
+	// automatically computed when the joint is created.
+	LocalSwingAxis1 Vec3
+
+	// The rotational spring and damper along the twist axis.
+	TwistSpringDamper *SpringDamper
+
+	// The rotational limit and motor along the twist axis.
+	TwistLimitMotor *RotationalLimitMotor
+
+	// The rotational spring and damper along the swing axis.
+	SwingSpringDamper *SpringDamper
+
+	// The max angle of rotation along the first swing axis.
+	// This value must be positive.
+	MaxSwingAngle1 float64
+
+	// The max angle of rotation along the second swing axis.
+	// This value must be positive.
+	MaxSwingAngle2 float64
+}
+
+func NewRagdollJointConfig() *RagdollJointConfig {
+	return &RagdollJointConfig{
+		JointConfig:       NewJointConfig(),
+		LocalTwistAxis1:   Vec3{1, 0, 0},
+		LocalTwistAxis2:   Vec3{1, 0, 0},
+		LocalSwingAxis1:   Vec3{0, 1, 0},
+		TwistSpringDamper: NewSpringDamper(),
+		TwistLimitMotor:   NewRotationalLimitMotor(),
+		SwingSpringDamper: NewSpringDamper(),
+		MaxSwingAngle1:    MathUtil.PI,
+		MaxSwingAngle2:    MathUtil.PI,
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local twist axes from the world twist axis
+// `worldTwistAxis`, local swing axis from the world swing axis `worldSwingAxis`, and returns `this`.
+func (self *RagdollJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor, worldTwistAxis, worldSwingAxis Vec3) *RagdollJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+	rigidBody1.GetLocalVectorTo(worldTwistAxis, &self.LocalTwistAxis1)
+	rigidBody2.GetLocalVectorTo(worldTwistAxis, &self.LocalTwistAxis2)
+	rigidBody1.GetLocalVectorTo(worldSwingAxis, &self.LocalSwingAxis1)
+	return self
+}
@@ -0,0 +1,28 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRagdollJointLimits(t *testing.T) {
+	w, base, box := jointTestWorld()
+	config := NewRagdollJointConfig().Init(base, box, Vec3{}, Vec3{1, 0, 0}, Vec3{0, 1, 0})
+	config.TwistLimitMotor.SetLimits(-0.2, 0.2)
+	config.MaxSwingAngle1 = 0.5
+	config.MaxSwingAngle2 = 0.5
+	j := NewRagdollJoint(config)
+	w.AddJoint(j.Joint)
+
+	// twist and swing the box at once, faster than the limits allow a step to overshoot by much
+	box.SetAngularVelocity(Vec3{1, 0, 1})
+	for range 90 {
+		stepWorld(w, 1)
+		if twist, swing := j.GetTwistAngle(), j.GetSwingAngle(); math.Abs(twist) > 0.2+1.0/60+Settings.AngularSlop || swing > 0.5+1.0/60+Settings.AngularSlop {
+			t.Fatalf("rotated past the limits: twist %v, swing %v", twist, swing)
+		}
+	}
+	if twist, swing := j.GetTwistAngle(), j.GetSwingAngle(); twist < 0.2-Settings.AngularSlop || swing < 0.5-Settings.AngularSlop {
+		t.Fatalf("didn't reach the limits: twist %v, swing %v", twist, swing)
+	}
+}
@@ -0,0 +1,49 @@
+package demos
+
+//////////////////////////////////////////////// RayCastClosest
+// (oimo/dynamics/callback/RayCastClosest.go)
+// A ray cast callback implementation that keeps only the closest hit data. This is reusable, but make sure to clear
+// the old result by calling `RayCastClosest.Clear` if used once or more before.
+
+type RayCastClosest struct { // implements IRayCastCallback
+	// The shape the ray hit.
+	Shape *Shape
+
+	// The position the ray hit at.
+	Position Vec3
+
+	// The normal vector of the surface the ray hit.
+	Normal Vec3
+
+	// The ratio of the position the ray hit from the start point to the end point.
+	Fraction float64
+
+	// Whether the ray hit any shape in the world.
+	Hit bool
+}
+
+// Default constructor.
+func NewRayCastClosest() *RayCastClosest {
+	r := &RayCastClosest{}
+	r.Clear()
+	return r
+}
+
+// Clears the result data.
+func (self *RayCastClosest) Clear() {
+	self.Shape = nil
+	self.Fraction = 1
+	self.Position.Zero()
+	self.Normal.Zero()
+	self.Hit = false
+}
+
+func (self *RayCastClosest) Process(shape *Shape, hit *RayCastHit) { // override
+	if hit.Fraction < self.Fraction {
+		self.Shape = shape
+		self.Hit = true
+		self.Fraction = hit.Fraction
+		self.Position = hit.Position
+		self.Normal = hit.Normal
+	}
+}
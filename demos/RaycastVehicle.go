@@ -0,0 +1,233 @@
+package demos
+
+import "math"
+
+//////////////////////////////////////////////// RaycastVehicle
+// (goimo)
+// A raycast vehicle drives a chassis rigid body on wheels that are ray casts instead of rigid bodies. Each wheel pushes
+// the chassis up by a spring and damper suspension, and drives, brakes and steers it by the friction of its tire,
+// which slips once the impulse it needs exceeds its grip. Call `RaycastVehicle.Update` before each `World.step`.
+
+type RaycastVehicle struct {
+	world   *World
+	chassis *RigidBody
+
+	// the axes of the chassis in its local coordinates
+	up      Vec3
+	forward Vec3
+
+	wheels []*VehicleWheel
+
+	rayCallback *vehicleRayCastCallback
+}
+
+// keeps the closest hit of the shapes other than the chassis
+type vehicleRayCastCallback struct { // implements IRayCastCallback
+	*RayCastClosest
+	chassis *RigidBody
+}
+
+func (self *vehicleRayCastCallback) Process(shape *Shape, hit *RayCastHit) { // override
+	if shape.rigidBody != self.chassis && !shape.trigger {
+		self.RayCastClosest.Process(shape, hit)
+	}
+}
+
+// Creates a new raycast vehicle driving the rigid body `chassis` in the world `world` by configuration `config`. The
+// vehicle has no wheels until they are added by `RaycastVehicle.AddWheel`.
+func NewRaycastVehicle(world *World, chassis *RigidBody, config *RaycastVehicleConfig) *RaycastVehicle {
+	return &RaycastVehicle{
+		world:       world,
+		chassis:     chassis,
+		up:          config.Up.Normalized(),
+		forward:     config.Forward.Normalized(),
+		rayCallback: &vehicleRayCastCallback{RayCastClosest: NewRayCastClosest(), chassis: chassis},
+	}
+}
+
+// --- internal ---
+
+// returns the velocity of the point `position` of the rigid body `rb`
+func vehiclePointVelocity(rb *RigidBody, position Vec3) Vec3 {
+	r := position.Sub(rb.transform.position)
+	v := rb.angVel.Cross(r)
+	v.AddEq(rb.vel)
+	return v
+}
+
+// returns the inverse of the mass the rigid body `rb` resists an impulse along `dir` at `position` with
+func vehicleInvMass(rb *RigidBody, position, dir Vec3) float64 {
+	if rb._type != RigidBodyType_DYNAMIC {
+		return 0
+	}
+	r := position.Sub(rb.transform.position)
+	rd := r.Cross(dir)
+	var ird Vec3
+	MathUtil.Vec3_mulMat3(&ird, &rd, &rb.invInertia)
+	return rb.invMass + rd.Dot(ird)
+}
+
+// returns the velocity of the chassis relative to the ground at the contact point of `wheel`
+func (self *RaycastVehicle) relativeVelocity(wheel *VehicleWheel) Vec3 {
+	v := vehiclePointVelocity(self.chassis, wheel.contactPosition)
+	v.SubEq(vehiclePointVelocity(wheel.contactShape.rigidBody, wheel.contactPosition))
+	return v
+}
+
+// returns the inverse of the mass the chassis and the ground resist an impulse along `dir` at the contact point of
+// `wheel` with
+func (self *RaycastVehicle) invMass(wheel *VehicleWheel, dir Vec3) float64 {
+	return vehicleInvMass(self.chassis, wheel.contactPosition, dir) + vehicleInvMass(wheel.contactShape.rigidBody, wheel.contactPosition, dir)
+}
+
+// applies `impulse` to the chassis at the contact point of `wheel`, and the reaction to the ground if it's dynamic
+func (self *RaycastVehicle) applyImpulse(wheel *VehicleWheel, impulse Vec3) {
+	self.chassis.ApplyImpulse(impulse, wheel.contactPosition)
+	if ground := wheel.contactShape.rigidBody; ground._type == RigidBodyType_DYNAMIC {
+		ground.ApplyImpulse(impulse.Negate(), wheel.contactPosition)
+	}
+}
+
+// casts the ray of `wheel` down from its suspension point, and updates the contact and the suspension length
+func (self *RaycastVehicle) castWheel(wheel *VehicleWheel) {
+	maxLength := wheel.restLength + wheel.maxTravel
+	begin := self.chassis.GetWorldPoint(wheel.position)
+	down := self.chassis.GetWorldVector(self.up.Negate())
+	end := begin.AddScaled(down, maxLength+wheel.radius)
+
+	self.rayCallback.Clear()
+	self.world.RayCast(begin, end, self.rayCallback)
+	if !self.rayCallback.Hit {
+		wheel.inContact = false
+		wheel.contactPosition.Zero()
+		wheel.contactNormal.Zero()
+		wheel.contactShape = nil
+		wheel.suspensionLength = maxLength
+		wheel.suspensionForce = 0
+		wheel.slip = 0
+		return
+	}
+
+	wheel.inContact = true
+	wheel.contactPosition = self.rayCallback.Position
+	wheel.contactNormal = self.rayCallback.Normal
+	wheel.contactShape = self.rayCallback.Shape
+	length := self.rayCallback.Fraction*(maxLength+wheel.radius) - wheel.radius
+	wheel.suspensionLength = MathUtil.Clamp(length, wheel.restLength-wheel.maxTravel, maxLength)
+}
+
+// pushes the chassis up from the ground by the suspension of `wheel`
+func (self *RaycastVehicle) applySuspension(wheel *VehicleWheel, timeStep float64) {
+	// negative while compressing
+	velocity := self.relativeVelocity(wheel)
+	normalSpeed := velocity.Dot(wheel.contactNormal)
+	damping := wheel.relaxationDamping
+	if normalSpeed < 0 {
+		damping = wheel.compressionDamping
+	}
+
+	force := wheel.stiffness*(wheel.restLength-wheel.suspensionLength) - damping*normalSpeed
+	wheel.suspensionForce = max(force*self.chassis.mass, 0)
+	self.applyImpulse(wheel, wheel.contactNormal.Scale(wheel.suspensionForce*timeStep))
+}
+
+// drives, brakes and holds the chassis sideways by the tire friction of `wheel`, and rolls the wheel
+func (self *RaycastVehicle) applyFriction(wheel *VehicleWheel, timeStep float64) {
+	if !wheel.inContact {
+		wheel.rotation = math.Mod(wheel.rotation+wheel.spinSpeed*timeStep, MathUtil.TWO_PI)
+		return
+	}
+
+	// the rolling and the axle directions of the wheel along the ground
+	localForward := wheel.localForward()
+	axle := self.chassis.GetWorldVector(localForward.Cross(self.up))
+	forward := wheel.contactNormal.Cross(axle)
+	if forward.LengthSq() < 1e-12 {
+		return // the ground is a wall to the wheel
+	}
+	forward.Normalize()
+	side := forward.Cross(wheel.contactNormal)
+
+	velocity := self.relativeVelocity(wheel)
+	forwardSpeed := velocity.Dot(forward)
+
+	// cancel the sideways velocity, and drive and brake along the rolling direction
+	sideImpulse := 0.0
+	if k := self.invMass(wheel, side); k > 0 {
+		sideImpulse = -velocity.Dot(side) / k
+	}
+	forwardImpulse := wheel.engineForce * timeStep
+	if wheel.brake > 0 {
+		if k := self.invMass(wheel, forward); k > 0 {
+			maxBrakeImpulse := wheel.brake * timeStep
+			forwardImpulse += MathUtil.Clamp(-forwardSpeed/k, -maxBrakeImpulse, maxBrakeImpulse)
+		}
+	}
+
+	// the tire slips once the impulse exceeds its grip
+	wheel.slip = 0
+	maxImpulse := wheel.frictionSlip * wheel.suspensionForce * timeStep
+	if impulse := MathUtil.Sqrt(forwardImpulse*forwardImpulse + sideImpulse*sideImpulse); impulse > maxImpulse {
+		scale := maxImpulse / impulse
+		forwardImpulse *= scale
+		sideImpulse *= scale
+		wheel.slip = 1 - scale
+	}
+
+	impulse := forward.Scale(forwardImpulse)
+	impulse.AddScaledEq(side, sideImpulse)
+	self.applyImpulse(wheel, impulse)
+
+	wheel.spinSpeed = forwardSpeed / wheel.radius
+	wheel.rotation = math.Mod(wheel.rotation+wheel.spinSpeed*timeStep, MathUtil.TWO_PI)
+}
+
+// --- public ---
+
+// Adds a wheel to the vehicle by configuration `config`, and returns it.
+func (self *RaycastVehicle) AddWheel(config *VehicleWheelConfig) *VehicleWheel {
+	wheel := newVehicleWheel(self, config)
+	self.wheels = append(self.wheels, wheel)
+	return wheel
+}
+
+// Updates the wheels and applies their suspension and tire impulses to the chassis for the time step `timeStep`.
+// Call this before each `World.step` with the same time step. Nothing is done while the chassis is sleeping, and
+// changing the driver's input of a wheel wakes it up.
+func (self *RaycastVehicle) Update(timeStep float64) {
+	if self.chassis.IsSleeping() {
+		return
+	}
+	for _, wheel := range self.wheels {
+		self.castWheel(wheel)
+	}
+	for _, wheel := range self.wheels {
+		if wheel.inContact {
+			self.applySuspension(wheel, timeStep)
+		}
+	}
+	for _, wheel := range self.wheels {
+		self.applyFriction(wheel, timeStep)
+	}
+}
+
+// Returns the chassis rigid body.
+func (self *RaycastVehicle) GetChassis() *RigidBody {
+	return self.chassis
+}
+
+// Returns the number of the wheels.
+func (self *RaycastVehicle) GetNumWheels() int {
+	return len(self.wheels)
+}
+
+// Returns the wheel at `index`, in the order they were added.
+func (self *RaycastVehicle) GetWheel(index int) *VehicleWheel {
+	return self.wheels[index]
+}
+
+// Returns the speed of the chassis along its forward direction. Negative speeds are backward.
+func (self *RaycastVehicle) GetSpeed() float64 {
+	forward := self.chassis.GetWorldVector(self.forward)
+	return self.chassis.vel.Dot(forward)
+}
@@ -0,0 +1,20 @@
+package demos
+
+//////////////////////////////////////////////// RaycastVehicleConfig
+// (goimo)
+// A raycast vehicle config is used for constructions of raycast vehicles.
+
+type RaycastVehicleConfig struct {
+	// The up direction of the chassis in its local coordinates. The suspensions extend against it.
+	Up Vec3
+
+	// The forward direction of the chassis in its local coordinates. The wheels roll along it when not steered.
+	Forward Vec3
+}
+
+func NewRaycastVehicleConfig() *RaycastVehicleConfig {
+	return &RaycastVehicleConfig{
+		Up:      Vec3{0, 1, 0},
+		Forward: Vec3{0, 0, -1},
+	}
+}
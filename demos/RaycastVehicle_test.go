@@ -0,0 +1,112 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+// creates a vehicle of mass 4 on four wheels, resting on the ground of `groundTestWorld`
+func newTestVehicle(w *World) *RaycastVehicle {
+	rc := NewRigidBodyConfig()
+	rc.Position = Vec3{0, 1.3, 0}
+	chassis := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{1, 0.25, 2})
+	chassis.AddShape(NewShape(sc))
+	w.AddRigidBody(chassis)
+
+	v := NewRaycastVehicle(w, chassis, NewRaycastVehicleConfig())
+	for _, p := range []Vec3{{-0.9, 0, -1.5}, {0.9, 0, -1.5}, {-0.9, 0, 1.5}, {0.9, 0, 1.5}} {
+		wc := NewVehicleWheelConfig()
+		wc.Position = p
+		wc.Radius = 0.35
+		v.AddWheel(wc)
+	}
+	return v
+}
+
+func driveVehicle(w *World, v *RaycastVehicle, numSteps int) {
+	for range numSteps {
+		v.Update(1.0 / 60)
+		w.Step(1.0 / 60)
+	}
+}
+
+func TestRaycastVehicleSuspension(t *testing.T) {
+	w := groundTestWorld()
+	v := newTestVehicle(w)
+	driveVehicle(w, v, 180)
+
+	// the springs are compressed by g / (4 * stiffness)
+	wc := NewVehicleWheelConfig()
+	length := wc.SuspensionRestLength - 9.80665/(4*wc.SuspensionStiffness)
+	if p := v.GetChassis().GetPosition(); math.Abs(p.y-(0.5+0.35+length)) > 0.02 {
+		t.Fatalf("chassis rests at %v", p)
+	}
+	for i := range v.GetNumWheels() {
+		wheel := v.GetWheel(i)
+		if !wheel.IsInContact() || wheel.GetContactShape() == nil || wheel.GetContactNormal().y < 0.99 {
+			t.Fatalf("wheel %d isn't on the ground", i)
+		}
+		// the wheel touches the ground
+		if tf := wheel.GetTransform(); math.Abs(tf.position.y-0.85) > 1e-3 {
+			t.Fatalf("wheel %d at %v", i, tf.position)
+		}
+	}
+}
+
+func TestRaycastVehicleDrive(t *testing.T) {
+	w := groundTestWorld()
+	v := newTestVehicle(w)
+	driveVehicle(w, v, 60)
+
+	// rear-wheel drive forward along -z
+	v.GetWheel(2).SetEngineForce(4)
+	v.GetWheel(3).SetEngineForce(4)
+	driveVehicle(w, v, 120)
+	p := v.GetChassis().GetPosition()
+	if p.z > -2 || math.Abs(p.x) > 0.05 || v.GetSpeed() < 1.5 {
+		t.Fatalf("drove to %v at speed %v", p, v.GetSpeed())
+	}
+	if v.GetWheel(0).GetRotation() == 0 {
+		t.Fatal("the wheels don't roll")
+	}
+
+	// too much engine force spins the tires
+	v.GetWheel(2).SetEngineForce(100)
+	driveVehicle(w, v, 1)
+	if v.GetWheel(2).GetSlip() == 0 {
+		t.Fatal("the tire didn't slip")
+	}
+
+	v.GetWheel(2).SetEngineForce(0)
+	v.GetWheel(3).SetEngineForce(0)
+	for i := range v.GetNumWheels() {
+		v.GetWheel(i).SetBrake(10)
+	}
+	driveVehicle(w, v, 120)
+	if math.Abs(v.GetSpeed()) > 0.01 {
+		t.Fatalf("didn't stop: speed %v", v.GetSpeed())
+	}
+}
+
+func TestRaycastVehicleSteering(t *testing.T) {
+	w := groundTestWorld()
+	v := newTestVehicle(w)
+	driveVehicle(w, v, 60)
+
+	v.GetWheel(0).SetSteering(0.3)
+	v.GetWheel(1).SetSteering(0.3)
+	v.GetWheel(2).SetEngineForce(4)
+	v.GetWheel(3).SetEngineForce(4)
+	driveVehicle(w, v, 180)
+
+	// turned left, toward -x
+	forward := v.GetChassis().GetWorldVector(Vec3{0, 0, -1})
+	if p := v.GetChassis().GetPosition(); p.x > -1 || forward.x > -0.3 {
+		t.Fatalf("didn't turn left: at %v facing %v", p, forward)
+	}
+	if up := v.GetChassis().GetWorldVector(Vec3{0, 1, 0}); up.y < 0.9 {
+		t.Fatalf("rolled over: up %v", up)
+	}
+}
@@ -0,0 +1,144 @@
+package demos
+
+//////////////////////////////////////////////// RevoluteJoint
+// (oimo/dynamics/constraint/joint/RevoluteJoint.go)
+// A revolute joint (a.k.a. hinge joint) constrains two rigid bodies to share their anchor points and constraint axes,
+// and restricts relative rotation onto the constraint axis. This joint provides one degree of freedom. You can enable
+// lower and upper limits, a motor, a spring and damper effect of the rotational part of the constraint.
+
+type RevoluteJoint struct {
+	*Joint
+
+	sd *SpringDamper
+	lm *RotationalLimitMotor
+
+	angle      float64
+	swingError Vec3
+}
+
+// Creates a new revolute joint by configuration `config`.
+func NewRevoluteJoint(config *RevoluteJointConfig) *RevoluteJoint {
+	j := &RevoluteJoint{
+		Joint: NewJoint(config.JointConfig, JointType_REVOLUTE),
+		sd:    config.SpringDamper.Clone(),
+		lm:    config.LimitMotor.Clone(),
+	}
+	j.impl = j
+
+	j.localBasisX1 = config.LocalAxis1
+	j.localBasisX2 = config.LocalAxis2
+	j.buildLocalBasesFromX()
+
+	return j
+}
+
+func (self *RevoluteJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	// compute rhs
+	angRhsY := self.swingError.Dot(self.basisY1) * erp
+	angRhsZ := self.swingError.Dot(self.basisZ1) * erp
+
+	// linear
+	self.addSphericalRows(info, erp)
+
+	// angular X
+	if self.sd.Frequency <= 0 || !isPositionPart {
+		row := info.AddRow(&self.impulses[3])
+		self.setSolverInfoRowAngular(row, self.angle, self.lm, self.computeEffectiveInertiaMoment(self.basisX1), self.sd, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.basisX1)
+	}
+
+	// angular Y
+	row := info.AddRow(&self.impulses[4])
+	row.EqualLimit(angRhsY, 0)
+	self.setJacobianAngular(row.jacobian, self.basisY1)
+
+	// angular Z
+	row = info.AddRow(&self.impulses[5])
+	row.EqualLimit(angRhsZ, 0)
+	self.setJacobianAngular(row.jacobian, self.basisZ1)
+}
+
+func (self *RevoluteJoint) computeErrors() {
+	self.swingError, self.angle = self.computeSwingTwist()
+}
+
+// --- internal ---
+
+func (self *RevoluteJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *RevoluteJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *RevoluteJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first rigid body's constraint axis in world coordinates.
+func (self *RevoluteJoint) GetAxis1() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second rigid body's constraint axis in world coordinates.
+func (self *RevoluteJoint) GetAxis2() Vec3 {
+	return self.basisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *RevoluteJoint) GetAxis1To(axis *Vec3) {
+	*axis = self.basisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *RevoluteJoint) GetAxis2To(axis *Vec3) {
+	*axis = self.basisX2
+}
+
+// Returns the first rigid body's constraint axis relative to the rigid body's transform.
+func (self *RevoluteJoint) GetLocalAxis1() Vec3 {
+	return self.localBasisX1
+}
+
+// Returns the second rigid body's constraint axis relative to the rigid body's transform.
+func (self *RevoluteJoint) GetLocalAxis2() Vec3 {
+	return self.localBasisX2
+}
+
+// Sets `axis` to the first rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *RevoluteJoint) GetLocalAxis1To(axis *Vec3) {
+	*axis = self.localBasisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *RevoluteJoint) GetLocalAxis2To(axis *Vec3) {
+	*axis = self.localBasisX2
+}
+
+// Returns the rotational spring and damper settings.
+func (self *RevoluteJoint) GetSpringDamper() *SpringDamper {
+	return self.sd
+}
+
+// Returns the rotational limits and motor settings.
+func (self *RevoluteJoint) GetLimitMotor() *RotationalLimitMotor {
+	return self.lm
+}
+
+// Returns the rotation angle in radians.
+func (self *RevoluteJoint) GetAngle() float64 {
+	return self.angle
+}
@@ -0,0 +1,39 @@
+package demos
+
+//////////////////////////////////////////////// RevoluteJointConfig
+// (oimo/dynamics/constraint/joint/RevoluteJointConfig.go)
+// A revolute joint config is used for constructions of revolute joints.
+
+type RevoluteJointConfig struct {
+	*JointConfig
+
+	// The first body's local constraint axis.
+	LocalAxis1 Vec3
+
+	// The second body's local constraint axis.
+	LocalAxis2 Vec3
+
+	// The rotational spring and damper settings.
+	SpringDamper *SpringDamper
+
+	// The rotational limits and motor settings.
+	LimitMotor *RotationalLimitMotor
+}
+
+func NewRevoluteJointConfig() *RevoluteJointConfig {
+	return &RevoluteJointConfig{
+		JointConfig:  NewJointConfig(),
+		LocalAxis1:   Vec3{1, 0, 0},
+		LocalAxis2:   Vec3{1, 0, 0},
+		SpringDamper: NewSpringDamper(),
+		LimitMotor:   NewRotationalLimitMotor(),
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local axes from the world axis `worldAxis`, and returns `this`.
+func (self *RevoluteJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor, worldAxis Vec3) *RevoluteJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+	rigidBody1.GetLocalVectorTo(worldAxis, &self.LocalAxis1)
+	rigidBody2.GetLocalVectorTo(worldAxis, &self.LocalAxis2)
+	return self
+}
@@ -59,6 +59,8 @@ type RigidBody struct {
 	addedToIsland bool
 	gravityScale  float64
 
+	articulationLink *ArticulationLink
+
 	userData any
 }
 
@@ -245,6 +247,30 @@ func (self *RigidBody) applyAngularPositionImpulse(imp Vec3) {
 	self.applyRotation(rotation)
 }
 
+// The constraint solvers write their results through the following methods, which leave static rigid bodies untouched
+// as they are shared by all the islands touching them.
+
+func (self *RigidBody) setSolvedVelocity(vel, angVel Vec3) {
+	if self._type != RigidBodyType_STATIC {
+		self.vel = vel
+		self.angVel = angVel
+	}
+}
+
+func (self *RigidBody) setSolvedPseudoVelocity(pseudoVel, angPseudoVel Vec3) {
+	if self._type != RigidBodyType_STATIC {
+		self.pseudoVel = pseudoVel
+		self.angPseudoVel = angPseudoVel
+	}
+}
+
+func (self *RigidBody) applySolvedDisplacement(translation, rotation Vec3) {
+	if self._type != RigidBodyType_STATIC {
+		self.applyTranslation(translation)
+		self.applyRotation(rotation)
+	}
+}
+
 // --- private ---
 
 func (self *RigidBody) _updateMass() {
@@ -428,6 +454,29 @@ func (self *RigidBody) GetTransformTo(transform *Transform) {
 	*transform = self.transform
 }
 
+// Returns the transform of the rigid body interpolated from the transform before the last step (`alpha = 0`) to the
+// current transform (`alpha = 1`). Pass `World.GetInterpolationAlpha()` to render the rigid body smoothly.
+func (self *RigidBody) GetInterpolatedTransform(alpha float64) Transform {
+	var tf Transform
+	self.GetInterpolatedTransformTo(alpha, &tf)
+	return tf
+}
+
+// Sets `transform` to the interpolated transform of the rigid body. See `RigidBody.GetInterpolatedTransform`.
+// This does not create a new instance of `Transform`.
+func (self *RigidBody) GetInterpolatedTransformTo(alpha float64, transform *Transform) {
+	tf0 := &self.pTransform
+	tf1 := &self.transform
+	transform.position = tf0.position.Scale(1 - alpha)
+	transform.position.AddScaledEq(tf1.position, alpha)
+
+	var q0, q1 Quat
+	MathUtil.Quat_fromMat3(&q0, &tf0.rotation)
+	MathUtil.Quat_fromMat3(&q1, &tf1.rotation)
+	MathUtil.Quat_slerp(&q0, &q0, &q1, alpha)
+	MathUtil.Mat3_fromQuat(&transform.rotation, &q0)
+}
+
 // Sets the transform of the rigid body to `transform`.
 // This does not keep any references to `transform`.
 func (self *RigidBody) SetTransform(transform *Transform) {
@@ -0,0 +1,72 @@
+package demos
+
+//////////////////////////////////////////////// RotationalLimitMotor
+// (oimo/dynamics/constraint/joint/RotationalLimitMotor.go)
+// Rotational limits and motor settings of a joint.
+
+type RotationalLimitMotor struct {
+	// The lower bound of the limit in radians.
+	// The limit will be disabled if `lowerLimit > upperLimit`.
+	LowerLimit float64
+
+	// The upper bound of the limit in radians.
+	// The limit will be disabled if `lowerLimit > upperLimit`.
+	UpperLimit float64
+
+	// The target speed of the motor in radians per second.
+	MotorSpeed float64
+
+	// The maximum torque of the motor in newton meters.
+	// The motor will be disabled if `motorTorque <= 0`.
+	MotorTorque float64
+
+	// The target angle of the servo in radians. The servo pulls the angle toward this, and damps the speed
+	// toward `motorSpeed`, using the motor with the maximum torque of `motorTorque`.
+	ServoTarget float64
+
+	// The stiffness of the servo in newton meters per radian.
+	// The servo will be disabled if both `servoStiffness` and `servoDamping` are `0`.
+	ServoStiffness float64
+
+	// The damping of the servo in newton meter seconds per radian.
+	ServoDamping float64
+}
+
+func NewRotationalLimitMotor() *RotationalLimitMotor {
+	return &RotationalLimitMotor{
+		LowerLimit: 1,
+		UpperLimit: 0,
+	}
+}
+
+// Sets limit properties at once and returns `this`.
+// `this.lowerLimit` is set to `lower`, and `this.upperLimit` is set to `upper`.
+func (self *RotationalLimitMotor) SetLimits(lower, upper float64) *RotationalLimitMotor {
+	self.LowerLimit = lower
+	self.UpperLimit = upper
+	return self
+}
+
+// Sets motor properties at once and returns `this`.
+// `this.motorSpeed` is set to `speed`, and `this.motorTorque` is set to `torque`.
+func (self *RotationalLimitMotor) SetMotor(speed, torque float64) *RotationalLimitMotor {
+	self.MotorSpeed = speed
+	self.MotorTorque = torque
+	return self
+}
+
+// Sets servo properties at once and returns `this`.
+// `this.servoTarget` is set to `target`, `this.servoStiffness` is set to `stiffness`, and `this.servoDamping` is set
+// to `damping`.
+func (self *RotationalLimitMotor) SetServo(target, stiffness, damping float64) *RotationalLimitMotor {
+	self.ServoTarget = target
+	self.ServoStiffness = stiffness
+	self.ServoDamping = damping
+	return self
+}
+
+// Returns a clone of the object
+func (self *RotationalLimitMotor) Clone() *RotationalLimitMotor {
+	lm := *self
+	return &lm
+}
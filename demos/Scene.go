@@ -0,0 +1,935 @@
+package demos
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+//////////////////////////////////////////////// Scene
+// (goimo)
+// The scene format saves the rigid bodies, shapes, materials, joints, gravity and solver settings of a world, see
+// `SaveWorld` and `LoadWorld`. Rigid bodies, materials and joints are referred to by their indices in the scene.
+
+// The version of the scene format written by `SaveWorld`. `LoadWorld` reads this and all older versions.
+const sceneVersion = 1
+
+// The bytes the binary encoding starts with, JSON never does.
+var sceneBinaryMagic = []byte("OIMOSCN\x00")
+
+var sceneBroadPhaseTypes = map[BroadPhaseType]string{
+	BroadPhaseType_BRUTE_FORCE: "brute_force",
+	BroadPhaseType_BVH:         "bvh",
+}
+
+var sceneRigidBodyTypes = map[RigidBodyType]string{
+	RigidBodyType_DYNAMIC:   "dynamic",
+	RigidBodyType_STATIC:    "static",
+	RigidBodyType_KINEMATIC: "kinematic",
+}
+
+var sceneGeometryTypes = map[GeometryType]string{
+	GeometryType_BOX:     "box",
+	GeometryType_CAPSULE: "capsule",
+}
+
+var sceneMaterialCombineModes = map[MaterialCombineMode]string{
+	MaterialCombineMode_GEOMETRIC_MEAN: "geometric_mean",
+	MaterialCombineMode_AVERAGE:        "average",
+	MaterialCombineMode_MIN:            "min",
+	MaterialCombineMode_MULTIPLY:       "multiply",
+	MaterialCombineMode_MAX:            "max",
+}
+
+var sceneJointTypes = map[JointType]string{
+	JointType_REVOLUTE:    "revolute",
+	JointType_CYLINDRICAL: "cylindrical",
+	JointType_PRISMATIC:   "prismatic",
+	JointType_UNIVERSAL:   "universal",
+	JointType_RAGDOLL:     "ragdoll",
+	JointType_GENERIC:     "generic",
+	JointType_DISTANCE:    "distance",
+	JointType_GEAR:        "gear",
+	JointType_PULLEY:      "pulley",
+	JointType_MOUSE:       "mouse",
+}
+
+var sceneConstraintSolverTypes = map[ConstraintSolverType]string{
+	ConstraintSolverType_ITERATIVE: "iterative",
+	ConstraintSolverType_DIRECT:    "direct",
+}
+
+var scenePositionCorrectionAlgorithms = map[PositionCorrectionAlgorithm]string{
+	PositionCorrectionAlgorithm_BAUMGARTE:     "baumgarte",
+	PositionCorrectionAlgorithm_SPLIT_IMPULSE: "split_impulse",
+	PositionCorrectionAlgorithm_NGS:           "ngs",
+}
+
+type sceneDocument struct {
+	Version int
+
+	BroadPhase            string
+	Gravity               [3]float64
+	NumVelocityIterations int
+	NumPositionIterations int
+	NumSubSteps           int
+	FixedTimeStep         float64
+	MaxSteps              int
+	NumWorkers            int
+	Deterministic         bool
+
+	Materials     []sceneMaterial
+	MaterialPairs []sceneMaterialPair
+	RigidBodies   []sceneRigidBody
+	Joints        []sceneJoint
+}
+
+type sceneMaterial struct {
+	StaticFriction         float64
+	DynamicFriction        float64
+	Restitution            float64
+	RollingFriction        float64
+	TorsionalFriction      float64
+	FrictionCombineMode    string
+	RestitutionCombineMode string
+}
+
+type sceneMaterialPair struct {
+	Material1 int
+	Material2 int
+	MaterialPair
+}
+
+type sceneRigidBody struct {
+	Type                             string
+	Position                         [3]float64
+	Rotation                         [9]float64
+	LinearVelocity                   [3]float64
+	AngularVelocity                  [3]float64
+	LinearDamping                    float64
+	AngularDamping                   float64
+	AutoSleep                        bool
+	SleepingVelocityThreshold        float64
+	SleepingAngularVelocityThreshold float64
+	SleepingTimeThreshold            float64
+	GravityScale                     float64
+	RotationFactor                   [3]float64
+	Sleeping                         bool
+
+	Shapes []sceneShape
+}
+
+type sceneShape struct {
+	Geometry          sceneGeometry
+	Position          [3]float64
+	Rotation          [9]float64
+	Density           float64
+	Material          int
+	FrictionDirection [3]float64
+	FrictionScale1    float64
+	FrictionScale2    float64
+	SurfaceVelocity   [3]float64
+	ContactStiffness  float64
+	ContactDamping    float64
+	OneWayNormal      [3]float64
+	CollisionGroup    int
+	CollisionMask     int
+	Trigger           bool
+}
+
+// the parameters of the type of the geometry
+type sceneGeometry struct {
+	Type        string
+	HalfExtents [3]float64
+	Radius      float64 `json:",omitempty"`
+	HalfHeight  float64 `json:",omitempty"`
+}
+
+// the parameters common to all the joints, and the ones of the type of the joint
+type sceneJoint struct {
+	Type                        string
+	RigidBody1                  int
+	RigidBody2                  int
+	LocalAnchor1                [3]float64
+	LocalAnchor2                [3]float64
+	AllowCollision              bool
+	SolverType                  string
+	PositionCorrectionAlgorithm string
+	BreakForce                  float64
+	BreakTorque                 float64
+
+	Revolute    *sceneRevoluteJoint    `json:",omitempty"`
+	Cylindrical *sceneCylindricalJoint `json:",omitempty"`
+	Prismatic   *scenePrismaticJoint   `json:",omitempty"`
+	Universal   *sceneUniversalJoint   `json:",omitempty"`
+	Ragdoll     *sceneRagdollJoint     `json:",omitempty"`
+	Generic     *sceneGenericJoint     `json:",omitempty"`
+	Distance    *sceneDistanceJoint    `json:",omitempty"`
+	Gear        *sceneGearJoint        `json:",omitempty"`
+	Pulley      *scenePulleyJoint      `json:",omitempty"`
+	Mouse       *sceneMouseJoint       `json:",omitempty"`
+}
+
+type sceneRevoluteJoint struct {
+	LocalAxis1   [3]float64
+	LocalAxis2   [3]float64
+	SpringDamper SpringDamper
+	LimitMotor   RotationalLimitMotor
+}
+
+type sceneCylindricalJoint struct {
+	LocalAxis1                [3]float64
+	LocalAxis2                [3]float64
+	TranslationalLimitMotor   TranslationalLimitMotor
+	TranslationalSpringDamper SpringDamper
+	RotationalLimitMotor      RotationalLimitMotor
+	RotationalSpringDamper    SpringDamper
+}
+
+type scenePrismaticJoint struct {
+	LocalAxis1   [3]float64
+	LocalAxis2   [3]float64
+	LimitMotor   TranslationalLimitMotor
+	SpringDamper SpringDamper
+}
+
+type sceneUniversalJoint struct {
+	LocalAxis1    [3]float64
+	LocalAxis2    [3]float64
+	SpringDamper1 SpringDamper
+	SpringDamper2 SpringDamper
+	LimitMotor1   RotationalLimitMotor
+	LimitMotor2   RotationalLimitMotor
+}
+
+type sceneRagdollJoint struct {
+	LocalTwistAxis1   [3]float64
+	LocalTwistAxis2   [3]float64
+	LocalSwingAxis1   [3]float64
+	TwistSpringDamper SpringDamper
+	TwistLimitMotor   RotationalLimitMotor
+	SwingSpringDamper SpringDamper
+	MaxSwingAngle1    float64
+	MaxSwingAngle2    float64
+}
+
+type sceneGenericJoint struct {
+	LocalBasis1                [9]float64
+	LocalBasis2                [9]float64
+	TranslationalLimitMotors   [3]TranslationalLimitMotor
+	RotationalLimitMotors      [3]RotationalLimitMotor
+	TranslationalSpringDampers [3]SpringDamper
+	RotationalSpringDampers    [3]SpringDamper
+}
+
+type sceneDistanceJoint struct {
+	LimitMotor   TranslationalLimitMotor
+	SpringDamper SpringDamper
+}
+
+// the coordinates and the constant are kept so that loading doesn't reset the gear
+type sceneGearJoint struct {
+	Joint1      int
+	Joint2      int
+	Ratio       float64
+	Coordinate1 float64
+	Coordinate2 float64
+	Constant    float64
+}
+
+// the constant is kept so that loading doesn't reset the length of the rope
+type scenePulleyJoint struct {
+	GroundAnchor1 [3]float64
+	GroundAnchor2 [3]float64
+	Ratio         float64
+	Constant      float64
+}
+
+type sceneMouseJoint struct {
+	Target       [3]float64
+	SpringDamper SpringDamper
+	MaxForce     float64
+}
+
+// --- public ---
+
+// Writes the rigid bodies, shapes, materials, joints, gravity and solver settings of `world` to `w` in the scene
+// format, encoded as `format`. The simulation state beyond the positions, velocities and sleep states of the rigid
+// bodies is not saved, nor are callbacks and user data. Worlds with articulations or with shapes of geometries
+// other than boxes cannot be saved.
+func SaveWorld(w io.Writer, world *World, format SceneFormat) error {
+	doc, err := _saveScene(world)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case SceneFormat_JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(doc)
+	case SceneFormat_BINARY:
+		if _, err := w.Write(sceneBinaryMagic); err != nil {
+			return err
+		}
+		return gob.NewEncoder(w).Encode(doc)
+	}
+	return fmt.Errorf("scene: unknown format %d", format)
+}
+
+// Reads a world saved by `SaveWorld` from `r`. Both encodings are accepted.
+func LoadWorld(r io.Reader) (*World, error) {
+	br := bufio.NewReader(r)
+	doc := &sceneDocument{}
+	if magic, _ := br.Peek(len(sceneBinaryMagic)); bytes.Equal(magic, sceneBinaryMagic) {
+		br.Discard(len(sceneBinaryMagic))
+		if err := gob.NewDecoder(br).Decode(doc); err != nil {
+			return nil, fmt.Errorf("scene: %w", err)
+		}
+	} else if err := json.NewDecoder(br).Decode(doc); err != nil {
+		return nil, fmt.Errorf("scene: %w", err)
+	}
+	return _loadScene(doc)
+}
+
+// --- private ---
+
+func _sceneVec3(v Vec3) [3]float64 {
+	return [3]float64{v.x, v.y, v.z}
+}
+
+func _sceneToVec3(a [3]float64) Vec3 {
+	return Vec3{a[0], a[1], a[2]}
+}
+
+// row-major
+func _sceneMat3(m Mat3) [9]float64 {
+	return [9]float64{m.e00, m.e01, m.e02, m.e10, m.e11, m.e12, m.e20, m.e21, m.e22}
+}
+
+func _sceneToMat3(a [9]float64) Mat3 {
+	return Mat3{a[0], a[1], a[2], a[3], a[4], a[5], a[6], a[7], a[8]}
+}
+
+// returns the value named `name` in `names`
+func _sceneParse[T comparable](names map[T]string, name string, what string) (T, error) {
+	for value, n := range names {
+		if n == name {
+			return value, nil
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("scene: unknown %s %q", what, name)
+}
+
+// returns the element at `index`, or an error if the index is out of range
+func _sceneElement[T any](elements []T, index int, what string) (T, error) {
+	if index < 0 || index >= len(elements) {
+		var zero T
+		return zero, fmt.Errorf("scene: %s index %d out of range", what, index)
+	}
+	return elements[index], nil
+}
+
+func _saveScene(world *World) (*sceneDocument, error) {
+	if world.numArticulations > 0 {
+		return nil, errors.New("scene: articulations cannot be saved")
+	}
+
+	doc := &sceneDocument{
+		Version:               sceneVersion,
+		BroadPhase:            sceneBroadPhaseTypes[BroadPhaseType_BRUTE_FORCE],
+		Gravity:               _sceneVec3(world.gravity),
+		NumVelocityIterations: world.numVelocityIterations,
+		NumPositionIterations: world.numPositionIterations,
+		NumSubSteps:           world.numSubSteps,
+		FixedTimeStep:         world.fixedTimeStep,
+		MaxSteps:              world.maxSteps,
+		NumWorkers:            world.numWorkers,
+		Deterministic:         world.deterministic,
+	}
+	if _, ok := world.broadPhase.(*BvhBroadPhase); ok {
+		doc.BroadPhase = sceneBroadPhaseTypes[BroadPhaseType_BVH]
+	}
+
+	// rigid bodies and shapes, materials are added as the shapes use them
+	materials := make(map[*Material]int)
+	bodies := make(map[*RigidBody]int)
+	for b := world.rigidBodyList; b != nil; b = b.next {
+		bodies[b] = len(doc.RigidBodies)
+		sb := sceneRigidBody{
+			Type:                             sceneRigidBodyTypes[b._type],
+			Position:                         _sceneVec3(b.transform.position),
+			Rotation:                         _sceneMat3(b.transform.rotation),
+			LinearVelocity:                   _sceneVec3(b.vel),
+			AngularVelocity:                  _sceneVec3(b.angVel),
+			LinearDamping:                    b.linearDamping,
+			AngularDamping:                   b.angularDamping,
+			AutoSleep:                        b.autoSleep,
+			SleepingVelocityThreshold:        b.sleepingVelocityThreshold,
+			SleepingAngularVelocityThreshold: b.sleepingAngularVelocityThreshold,
+			SleepingTimeThreshold:            b.sleepingTimeThreshold,
+			GravityScale:                     b.gravityScale,
+			RotationFactor:                   _sceneVec3(b.rotFactor),
+			Sleeping:                         b.sleeping,
+		}
+		for s := b.shapeList; s != nil; s = s.next {
+			geometry, err := _saveSceneGeometry(s.geom)
+			if err != nil {
+				return nil, err
+			}
+			material, ok := materials[s.material]
+			if !ok {
+				material = len(doc.Materials)
+				materials[s.material] = material
+				doc.Materials = append(doc.Materials, _saveSceneMaterial(s.material))
+			}
+			sb.Shapes = append(sb.Shapes, sceneShape{
+				Geometry:          geometry,
+				Position:          _sceneVec3(s.localTransform.position),
+				Rotation:          _sceneMat3(s.localTransform.rotation),
+				Density:           s.density,
+				Material:          material,
+				FrictionDirection: _sceneVec3(s.frictionDirection),
+				FrictionScale1:    s.frictionScale1,
+				FrictionScale2:    s.frictionScale2,
+				SurfaceVelocity:   _sceneVec3(s.surfaceVelocity),
+				ContactStiffness:  s.contactStiffness,
+				ContactDamping:    s.contactDamping,
+				OneWayNormal:      _sceneVec3(s.oneWayNormal),
+				CollisionGroup:    s.collisionGroup,
+				CollisionMask:     s.collisionMask,
+				Trigger:           s.trigger,
+			})
+		}
+		doc.RigidBodies = append(doc.RigidBodies, sb)
+	}
+
+	// material pairs of materials no shape uses have no effect, the table has both orders of each pair
+	for key, pair := range world.materialPairs {
+		m1, ok1 := materials[key.m1]
+		m2, ok2 := materials[key.m2]
+		if ok1 && ok2 && m1 <= m2 {
+			doc.MaterialPairs = append(doc.MaterialPairs, sceneMaterialPair{m1, m2, *pair})
+		}
+	}
+	slices.SortFunc(doc.MaterialPairs, func(a, b sceneMaterialPair) int {
+		return cmp.Or(cmp.Compare(a.Material1, b.Material1), cmp.Compare(a.Material2, b.Material2))
+	})
+
+	// joints, indexed first as gear joints refer to other joints
+	joints := make(map[*Joint]int)
+	for j := world.jointList; j != nil; j = j.next {
+		joints[j] = len(joints)
+	}
+	for j := world.jointList; j != nil; j = j.next {
+		sj, err := _saveSceneJoint(j, bodies, joints)
+		if err != nil {
+			return nil, err
+		}
+		doc.Joints = append(doc.Joints, sj)
+	}
+
+	return doc, nil
+}
+
+func _saveSceneMaterial(m *Material) sceneMaterial {
+	return sceneMaterial{
+		StaticFriction:         m.StaticFriction,
+		DynamicFriction:        m.DynamicFriction,
+		Restitution:            m.Restitution,
+		RollingFriction:        m.RollingFriction,
+		TorsionalFriction:      m.TorsionalFriction,
+		FrictionCombineMode:    sceneMaterialCombineModes[m.FrictionCombineMode],
+		RestitutionCombineMode: sceneMaterialCombineModes[m.RestitutionCombineMode],
+	}
+}
+
+func _saveSceneGeometry(g IGeometry) (sceneGeometry, error) {
+	sg := sceneGeometry{Type: sceneGeometryTypes[g.GetType()]}
+	switch impl := g.(type) {
+	case *BoxGeometry:
+		sg.HalfExtents = _sceneVec3(impl.halfExtents)
+	case *CapsuleGeometry:
+		sg.Radius = impl.radius
+		sg.HalfHeight = impl.halfHeight
+	default:
+		return sg, fmt.Errorf("scene: geometry type %d cannot be saved", g.GetType())
+	}
+	return sg, nil
+}
+
+func _saveSceneJoint(j *Joint, bodies map[*RigidBody]int, joints map[*Joint]int) (sceneJoint, error) {
+	solverType := ConstraintSolverType_ITERATIVE
+	if _, ok := j.solver.(*DirectJointConstraintSolver); ok {
+		solverType = ConstraintSolverType_DIRECT
+	}
+	sj := sceneJoint{
+		Type:                        sceneJointTypes[j._type],
+		RigidBody1:                  bodies[j.b1],
+		RigidBody2:                  bodies[j.b2],
+		LocalAnchor1:                _sceneVec3(j.localAnchor1),
+		LocalAnchor2:                _sceneVec3(j.localAnchor2),
+		AllowCollision:              j.allowCollision,
+		SolverType:                  sceneConstraintSolverTypes[solverType],
+		PositionCorrectionAlgorithm: scenePositionCorrectionAlgorithms[j.positionCorrectionAlgorithm],
+		BreakForce:                  j.breakForce,
+		BreakTorque:                 j.breakTorque,
+	}
+
+	switch impl := j.impl.(type) {
+	case *RevoluteJoint:
+		sj.Revolute = &sceneRevoluteJoint{
+			LocalAxis1:   _sceneVec3(j.localBasisX1),
+			LocalAxis2:   _sceneVec3(j.localBasisX2),
+			SpringDamper: *impl.sd,
+			LimitMotor:   *impl.lm,
+		}
+	case *CylindricalJoint:
+		sj.Cylindrical = &sceneCylindricalJoint{
+			LocalAxis1:                _sceneVec3(j.localBasisX1),
+			LocalAxis2:                _sceneVec3(j.localBasisX2),
+			TranslationalLimitMotor:   *impl.translLm,
+			TranslationalSpringDamper: *impl.translSd,
+			RotationalLimitMotor:      *impl.rotLm,
+			RotationalSpringDamper:    *impl.rotSd,
+		}
+	case *PrismaticJoint:
+		sj.Prismatic = &scenePrismaticJoint{
+			LocalAxis1:   _sceneVec3(j.localBasisX1),
+			LocalAxis2:   _sceneVec3(j.localBasisX2),
+			LimitMotor:   *impl.lm,
+			SpringDamper: *impl.sd,
+		}
+	case *UniversalJoint:
+		sj.Universal = &sceneUniversalJoint{
+			LocalAxis1:    _sceneVec3(j.localBasisX1),
+			LocalAxis2:    _sceneVec3(j.localBasisZ2),
+			SpringDamper1: *impl.sd1,
+			SpringDamper2: *impl.sd2,
+			LimitMotor1:   *impl.lm1,
+			LimitMotor2:   *impl.lm2,
+		}
+	case *RagdollJoint:
+		sj.Ragdoll = &sceneRagdollJoint{
+			LocalTwistAxis1:   _sceneVec3(j.localBasisX1),
+			LocalTwistAxis2:   _sceneVec3(j.localBasisX2),
+			LocalSwingAxis1:   _sceneVec3(j.localBasisY1),
+			TwistSpringDamper: *impl.twistSd,
+			TwistLimitMotor:   *impl.twistLm,
+			SwingSpringDamper: *impl.swingSd,
+			MaxSwingAngle1:    impl.maxSwingAngle1,
+			MaxSwingAngle2:    impl.maxSwingAngle2,
+		}
+	case *GenericJoint:
+		var basis1, basis2 Mat3
+		MathUtil.Mat3_fromCols(&basis1, &j.localBasisX1, &j.localBasisY1, &j.localBasisZ1)
+		MathUtil.Mat3_fromCols(&basis2, &j.localBasisX2, &j.localBasisY2, &j.localBasisZ2)
+		generic := &sceneGenericJoint{
+			LocalBasis1: _sceneMat3(basis1),
+			LocalBasis2: _sceneMat3(basis2),
+		}
+		for i := range 3 {
+			generic.TranslationalLimitMotors[i] = *impl.translLms[i]
+			generic.RotationalLimitMotors[i] = *impl.rotLms[i]
+			generic.TranslationalSpringDampers[i] = *impl.translSds[i]
+			generic.RotationalSpringDampers[i] = *impl.rotSds[i]
+		}
+		sj.Generic = generic
+	case *DistanceJoint:
+		sj.Distance = &sceneDistanceJoint{
+			LimitMotor:   *impl.lm,
+			SpringDamper: *impl.sd,
+		}
+	case *GearJoint:
+		joint1, ok1 := joints[impl.joint1]
+		joint2, ok2 := joints[impl.joint2]
+		if !ok1 || !ok2 {
+			return sj, errors.New("scene: a gear joint couples a joint not in the world")
+		}
+		sj.Gear = &sceneGearJoint{
+			Joint1:      joint1,
+			Joint2:      joint2,
+			Ratio:       impl.ratio,
+			Coordinate1: impl.coordinate1,
+			Coordinate2: impl.coordinate2,
+			Constant:    impl.constant,
+		}
+	case *PulleyJoint:
+		sj.Pulley = &scenePulleyJoint{
+			GroundAnchor1: _sceneVec3(impl.groundAnchor1),
+			GroundAnchor2: _sceneVec3(impl.groundAnchor2),
+			Ratio:         impl.ratio,
+			Constant:      impl.constant,
+		}
+	case *MouseJoint:
+		sj.Mouse = &sceneMouseJoint{
+			Target:       _sceneVec3(impl.target),
+			SpringDamper: *impl.sd,
+			MaxForce:     impl.maxForce,
+		}
+	default:
+		return sj, fmt.Errorf("scene: joint type %d cannot be saved", j._type)
+	}
+	return sj, nil
+}
+
+func _loadScene(doc *sceneDocument) (*World, error) {
+	if doc.Version < 1 || doc.Version > sceneVersion {
+		return nil, fmt.Errorf("scene: unsupported version %d", doc.Version)
+	}
+
+	broadPhaseType, err := _sceneParse(sceneBroadPhaseTypes, doc.BroadPhase, "broad-phase type")
+	if err != nil {
+		return nil, err
+	}
+	if doc.FixedTimeStep <= 0 || doc.MaxSteps < 1 {
+		return nil, fmt.Errorf("scene: invalid fixed time step %v or maximum steps %d", doc.FixedTimeStep, doc.MaxSteps)
+	}
+	gravity := _sceneToVec3(doc.Gravity)
+	world := NewWorld(broadPhaseType, &gravity)
+	world.SetNumVelocityIterations(doc.NumVelocityIterations)
+	world.SetNumPositionIterations(doc.NumPositionIterations)
+	world.SetNumSubSteps(doc.NumSubSteps)
+	world.SetFixedTimeStep(doc.FixedTimeStep)
+	world.SetMaxSteps(doc.MaxSteps)
+	world.SetNumWorkers(doc.NumWorkers)
+	world.SetDeterministic(doc.Deterministic)
+
+	// materials
+	materials := make([]*Material, len(doc.Materials))
+	for i := range doc.Materials {
+		if materials[i], err = _loadSceneMaterial(&doc.Materials[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range doc.MaterialPairs {
+		sp := &doc.MaterialPairs[i]
+		m1, err := _sceneElement(materials, sp.Material1, "material")
+		if err != nil {
+			return nil, err
+		}
+		m2, err := _sceneElement(materials, sp.Material2, "material")
+		if err != nil {
+			return nil, err
+		}
+		pair := sp.MaterialPair
+		world.SetMaterialPair(m1, m2, &pair)
+	}
+
+	// rigid bodies and shapes
+	bodies := make([]*RigidBody, len(doc.RigidBodies))
+	for i := range doc.RigidBodies {
+		if bodies[i], err = _loadSceneRigidBody(&doc.RigidBodies[i], materials); err != nil {
+			return nil, err
+		}
+		world.AddRigidBody(bodies[i])
+	}
+
+	// joints, gear joints are created last as they need the joints they couple
+	joints := make([]*Joint, len(doc.Joints))
+	for _, gears := range []bool{false, true} {
+		for i := range doc.Joints {
+			sj := &doc.Joints[i]
+			if (sj.Type == sceneJointTypes[JointType_GEAR]) != gears {
+				continue
+			}
+			if joints[i], err = _loadSceneJoint(sj, bodies, joints); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, j := range joints {
+		world.AddJoint(j)
+	}
+
+	// adding the joints woke the rigid bodies up
+	for i, b := range bodies {
+		if doc.RigidBodies[i].Sleeping {
+			b.Sleep()
+		}
+	}
+
+	return world, nil
+}
+
+func _loadSceneMaterial(sm *sceneMaterial) (*Material, error) {
+	frictionCombineMode, err := _sceneParse(sceneMaterialCombineModes, sm.FrictionCombineMode, "combine mode")
+	if err != nil {
+		return nil, err
+	}
+	restitutionCombineMode, err := _sceneParse(sceneMaterialCombineModes, sm.RestitutionCombineMode, "combine mode")
+	if err != nil {
+		return nil, err
+	}
+	return NewMaterial().
+		Init(sm.StaticFriction, sm.DynamicFriction, sm.Restitution).
+		SetRollingAndTorsionalFriction(sm.RollingFriction, sm.TorsionalFriction).
+		SetCombineModes(frictionCombineMode, restitutionCombineMode), nil
+}
+
+func _loadSceneGeometry(sg *sceneGeometry) (IGeometry, error) {
+	geometryType, err := _sceneParse(sceneGeometryTypes, sg.Type, "geometry type")
+	if err != nil {
+		return nil, err
+	}
+	switch geometryType {
+	case GeometryType_CAPSULE:
+		return NewCapsuleGeometry(sg.Radius, sg.HalfHeight), nil
+	default:
+		return NewBoxGeometry(_sceneToVec3(sg.HalfExtents)), nil
+	}
+}
+
+func _loadSceneRigidBody(sb *sceneRigidBody, materials []*Material) (*RigidBody, error) {
+	rigidBodyType, err := _sceneParse(sceneRigidBodyTypes, sb.Type, "rigid body type")
+	if err != nil {
+		return nil, err
+	}
+	rc := NewRigidBodyConfig()
+	rc.Type = rigidBodyType
+	rc.Position = _sceneToVec3(sb.Position)
+	rc.Rotation = _sceneToMat3(sb.Rotation)
+	rc.LinearVelocity = _sceneToVec3(sb.LinearVelocity)
+	rc.AngularVelocity = _sceneToVec3(sb.AngularVelocity)
+	rc.LinearDamping = sb.LinearDamping
+	rc.AngularDamping = sb.AngularDamping
+	rc.AutoSleep = sb.AutoSleep
+	rc.SleepingVelocityThreshold = sb.SleepingVelocityThreshold
+	rc.SleepingAngularVelocityThreshold = sb.SleepingAngularVelocityThreshold
+	rc.SleepingTimeThreshold = sb.SleepingTimeThreshold
+	b := NewRigidBody(rc)
+	b.SetGravityScale(sb.GravityScale)
+
+	for i := range sb.Shapes {
+		ss := &sb.Shapes[i]
+		geometry, err := _loadSceneGeometry(&ss.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		material, err := _sceneElement(materials, ss.Material, "material")
+		if err != nil {
+			return nil, err
+		}
+		sc := NewShapeConfig()
+		sc.Geometry = geometry
+		sc.Position = _sceneToVec3(ss.Position)
+		sc.Rotation = _sceneToMat3(ss.Rotation)
+		sc.Density = ss.Density
+		sc.Material = material
+		sc.FrictionDirection = _sceneToVec3(ss.FrictionDirection)
+		sc.FrictionScale1 = ss.FrictionScale1
+		sc.FrictionScale2 = ss.FrictionScale2
+		sc.SurfaceVelocity = _sceneToVec3(ss.SurfaceVelocity)
+		sc.ContactStiffness = ss.ContactStiffness
+		sc.ContactDamping = ss.ContactDamping
+		sc.OneWayNormal = _sceneToVec3(ss.OneWayNormal)
+		sc.CollisionGroup = ss.CollisionGroup
+		sc.CollisionMask = ss.CollisionMask
+		sc.Trigger = ss.Trigger
+		b.AddShape(NewShape(sc))
+	}
+
+	// after the shapes, as this needs the inertia
+	b.SetRotationFactor(_sceneToVec3(sb.RotationFactor))
+	return b, nil
+}
+
+func _loadSceneJointConfig(config *JointConfig, sj *sceneJoint, bodies []*RigidBody) error {
+	var err error
+	if config.RigidBody1, err = _sceneElement(bodies, sj.RigidBody1, "rigid body"); err != nil {
+		return err
+	}
+	if config.RigidBody2, err = _sceneElement(bodies, sj.RigidBody2, "rigid body"); err != nil {
+		return err
+	}
+	if config.SolverType, err = _sceneParse(sceneConstraintSolverTypes, sj.SolverType, "solver type"); err != nil {
+		return err
+	}
+	config.PositionCorrectionAlgorithm, err = _sceneParse(scenePositionCorrectionAlgorithms, sj.PositionCorrectionAlgorithm, "position correction algorithm")
+	if err != nil {
+		return err
+	}
+	config.LocalAnchor1 = _sceneToVec3(sj.LocalAnchor1)
+	config.LocalAnchor2 = _sceneToVec3(sj.LocalAnchor2)
+	config.AllowCollision = sj.AllowCollision
+	config.BreakForce = sj.BreakForce
+	config.BreakTorque = sj.BreakTorque
+	return nil
+}
+
+func _loadSceneJoint(sj *sceneJoint, bodies []*RigidBody, joints []*Joint) (*Joint, error) {
+	jointType, err := _sceneParse(sceneJointTypes, sj.Type, "joint type")
+	if err != nil {
+		return nil, err
+	}
+	missing := fmt.Errorf("scene: %s joint without its parameters", sj.Type)
+
+	switch jointType {
+	case JointType_REVOLUTE:
+		if sj.Revolute == nil {
+			return nil, missing
+		}
+		c := NewRevoluteJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalAxis1 = _sceneToVec3(sj.Revolute.LocalAxis1)
+		c.LocalAxis2 = _sceneToVec3(sj.Revolute.LocalAxis2)
+		c.SpringDamper = &sj.Revolute.SpringDamper
+		c.LimitMotor = &sj.Revolute.LimitMotor
+		return NewRevoluteJoint(c).Joint, nil
+
+	case JointType_CYLINDRICAL:
+		if sj.Cylindrical == nil {
+			return nil, missing
+		}
+		c := NewCylindricalJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalAxis1 = _sceneToVec3(sj.Cylindrical.LocalAxis1)
+		c.LocalAxis2 = _sceneToVec3(sj.Cylindrical.LocalAxis2)
+		c.TranslationalLimitMotor = &sj.Cylindrical.TranslationalLimitMotor
+		c.TranslationalSpringDamper = &sj.Cylindrical.TranslationalSpringDamper
+		c.RotationalLimitMotor = &sj.Cylindrical.RotationalLimitMotor
+		c.RotationalSpringDamper = &sj.Cylindrical.RotationalSpringDamper
+		return NewCylindricalJoint(c).Joint, nil
+
+	case JointType_PRISMATIC:
+		if sj.Prismatic == nil {
+			return nil, missing
+		}
+		c := NewPrismaticJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalAxis1 = _sceneToVec3(sj.Prismatic.LocalAxis1)
+		c.LocalAxis2 = _sceneToVec3(sj.Prismatic.LocalAxis2)
+		c.LimitMotor = &sj.Prismatic.LimitMotor
+		c.SpringDamper = &sj.Prismatic.SpringDamper
+		return NewPrismaticJoint(c).Joint, nil
+
+	case JointType_UNIVERSAL:
+		if sj.Universal == nil {
+			return nil, missing
+		}
+		c := NewUniversalJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalAxis1 = _sceneToVec3(sj.Universal.LocalAxis1)
+		c.LocalAxis2 = _sceneToVec3(sj.Universal.LocalAxis2)
+		c.SpringDamper1 = &sj.Universal.SpringDamper1
+		c.SpringDamper2 = &sj.Universal.SpringDamper2
+		c.LimitMotor1 = &sj.Universal.LimitMotor1
+		c.LimitMotor2 = &sj.Universal.LimitMotor2
+		return NewUniversalJoint(c).Joint, nil
+
+	case JointType_RAGDOLL:
+		if sj.Ragdoll == nil {
+			return nil, missing
+		}
+		c := NewRagdollJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalTwistAxis1 = _sceneToVec3(sj.Ragdoll.LocalTwistAxis1)
+		c.LocalTwistAxis2 = _sceneToVec3(sj.Ragdoll.LocalTwistAxis2)
+		c.LocalSwingAxis1 = _sceneToVec3(sj.Ragdoll.LocalSwingAxis1)
+		c.TwistSpringDamper = &sj.Ragdoll.TwistSpringDamper
+		c.TwistLimitMotor = &sj.Ragdoll.TwistLimitMotor
+		c.SwingSpringDamper = &sj.Ragdoll.SwingSpringDamper
+		c.MaxSwingAngle1 = sj.Ragdoll.MaxSwingAngle1
+		c.MaxSwingAngle2 = sj.Ragdoll.MaxSwingAngle2
+		return NewRagdollJoint(c).Joint, nil
+
+	case JointType_GENERIC:
+		if sj.Generic == nil {
+			return nil, missing
+		}
+		c := NewGenericJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LocalBasis1 = _sceneToMat3(sj.Generic.LocalBasis1)
+		c.LocalBasis2 = _sceneToMat3(sj.Generic.LocalBasis2)
+		for i := range 3 {
+			c.TranslationalLimitMotors[i] = &sj.Generic.TranslationalLimitMotors[i]
+			c.RotationalLimitMotors[i] = &sj.Generic.RotationalLimitMotors[i]
+			c.TranslationalSpringDampers[i] = &sj.Generic.TranslationalSpringDampers[i]
+			c.RotationalSpringDampers[i] = &sj.Generic.RotationalSpringDampers[i]
+		}
+		return NewGenericJoint(c).Joint, nil
+
+	case JointType_DISTANCE:
+		if sj.Distance == nil {
+			return nil, missing
+		}
+		c := NewDistanceJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.LimitMotor = &sj.Distance.LimitMotor
+		c.SpringDamper = &sj.Distance.SpringDamper
+		return NewDistanceJoint(c).Joint, nil
+
+	case JointType_GEAR:
+		if sj.Gear == nil {
+			return nil, missing
+		}
+		c := NewGearJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		if c.Joint1, err = _sceneElement(joints, sj.Gear.Joint1, "joint"); err != nil {
+			return nil, err
+		}
+		if c.Joint2, err = _sceneElement(joints, sj.Gear.Joint2, "joint"); err != nil {
+			return nil, err
+		}
+		if !_isGearCoupledJoint(c.Joint1) || !_isGearCoupledJoint(c.Joint2) {
+			return nil, errors.New("scene: a gear joint couples a joint other than a revolute or prismatic joint")
+		}
+		c.Ratio = sj.Gear.Ratio
+		j := NewGearJoint(c)
+		j.coordinate1 = sj.Gear.Coordinate1
+		j.coordinate2 = sj.Gear.Coordinate2
+		j.constant = sj.Gear.Constant
+		return j.Joint, nil
+
+	case JointType_PULLEY:
+		if sj.Pulley == nil {
+			return nil, missing
+		}
+		c := NewPulleyJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.GroundAnchor1 = _sceneToVec3(sj.Pulley.GroundAnchor1)
+		c.GroundAnchor2 = _sceneToVec3(sj.Pulley.GroundAnchor2)
+		c.Ratio = sj.Pulley.Ratio
+		j := NewPulleyJoint(c)
+		j.constant = sj.Pulley.Constant
+		return j.Joint, nil
+
+	case JointType_MOUSE:
+		if sj.Mouse == nil {
+			return nil, missing
+		}
+		c := NewMouseJointConfig()
+		if err := _loadSceneJointConfig(c.JointConfig, sj, bodies); err != nil {
+			return nil, err
+		}
+		c.Target = _sceneToVec3(sj.Mouse.Target)
+		c.SpringDamper = &sj.Mouse.SpringDamper
+		c.MaxForce = sj.Mouse.MaxForce
+		return NewMouseJoint(c).Joint, nil
+	}
+	return nil, fmt.Errorf("scene: joint type %q cannot be loaded", sj.Type)
+}
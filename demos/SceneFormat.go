@@ -0,0 +1,14 @@
+package demos
+
+//////////////////////////////////////////////// SceneFormat
+// (goimo)
+// The list of the encodings of the scene format, see `SaveWorld`.
+
+type SceneFormat int
+
+const (
+	// Human-readable JSON.
+	SceneFormat_JSON SceneFormat = iota
+	// Compact binary encoding.
+	SceneFormat_BINARY
+)
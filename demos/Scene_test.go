@@ -0,0 +1,131 @@
+package demos
+
+import (
+	"bytes"
+	"testing"
+)
+
+// adds boxes with a shared material and a material pair, and gear, distance and pulley joints to the pendulum towers
+func sceneTestWorld() *World {
+	w := parallelTestWorld(2, nil)
+	ground := w.GetRigidBodyList()
+
+	material := NewMaterial().Init(0.8, 0.6, 0.1).SetCombineModes(MaterialCombineMode_MAX, MaterialCombineMode_MIN)
+	w.SetMaterialPair(material, ground.GetShapeList().material, NewMaterialPair().Init(1, 1, 0))
+	boxes := make([]*RigidBody, 4)
+	for i := range boxes {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{float64(i)*2 + 5, 3, 0}
+		boxes[i] = NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.4, 0.4, 0.4})
+		sc.Material = material
+		boxes[i].AddShape(NewShape(sc))
+		w.AddRigidBody(boxes[i])
+	}
+
+	hinge1 := NewRevoluteJoint(NewRevoluteJointConfig().Init(ground, boxes[0], Vec3{5, 3, 0}, Vec3{0, 0, 1})).Joint
+	hinge2 := NewRevoluteJoint(NewRevoluteJointConfig().Init(ground, boxes[1], Vec3{7, 3, 0}, Vec3{0, 0, 1})).Joint
+	w.AddJoint(hinge1)
+	w.AddJoint(NewGearJoint(NewGearJointConfig().Init(hinge1, hinge2, 2)).Joint)
+	w.AddJoint(hinge2)
+	w.AddJoint(NewDistanceJoint(NewDistanceJointConfig().Init(boxes[0], boxes[2], Vec3{5, 3, 0}, Vec3{9, 3, 0})).Joint)
+	w.AddJoint(NewPulleyJoint(NewPulleyJointConfig().Init(boxes[2], boxes[3], Vec3{9, 6, 0}, Vec3{11, 6, 0}, Vec3{9, 3, 0}, Vec3{11, 3, 0}, 1)).Joint)
+	return w
+}
+
+func TestSaveLoadWorld(t *testing.T) {
+	w := sceneTestWorld()
+	w.SetNumSubSteps(2)
+	for range 20 {
+		w.Step(1.0 / 60)
+	}
+
+	var encoded [2]bytes.Buffer
+	var loaded [2]*World
+	for i, format := range []SceneFormat{SceneFormat_JSON, SceneFormat_BINARY} {
+		if err := SaveWorld(&encoded[i], w, format); err != nil {
+			t.Fatal(err)
+		}
+		var err error
+		if loaded[i], err = LoadWorld(bytes.NewReader(encoded[i].Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		if loaded[i].Checksum() != w.Checksum() || loaded[i].GetNumJoints() != w.GetNumJoints() {
+			t.Fatalf("format %d: the loaded world differs", format)
+		}
+	}
+	if encoded[1].Len() >= encoded[0].Len() {
+		t.Errorf("binary encoding is %d bytes, JSON is %d bytes", encoded[1].Len(), encoded[0].Len())
+	}
+
+	// saving the loaded world gives the same scene
+	var resaved bytes.Buffer
+	if err := SaveWorld(&resaved, loaded[1], SceneFormat_JSON); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(resaved.Bytes(), encoded[0].Bytes()) {
+		t.Fatal("the scene changed after loading")
+	}
+
+	for step := range 60 {
+		loaded[0].Step(1.0 / 60)
+		loaded[1].Step(1.0 / 60)
+		if loaded[0].Checksum() != loaded[1].Checksum() {
+			t.Fatalf("step %d: the loaded worlds diverged", step)
+		}
+	}
+
+	if _, err := LoadWorld(bytes.NewReader([]byte(`{"Version": 2}`))); err == nil {
+		t.Fatal("loaded a scene of a newer version")
+	}
+}
+
+func TestLoadWorldGearCouplingDistanceJoint(t *testing.T) {
+	doc, err := _saveScene(sceneTestWorld())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// couple the distance joint instead of the second hinge
+	distance := -1
+	for i := range doc.Joints {
+		if doc.Joints[i].Distance != nil {
+			distance = i
+		}
+	}
+	for i := range doc.Joints {
+		if doc.Joints[i].Gear != nil {
+			doc.Joints[i].Gear.Joint2 = distance
+		}
+	}
+	if _, err := _loadScene(doc); err == nil {
+		t.Fatal("loaded a gear joint coupling a distance joint")
+	}
+}
+
+func TestSaveLoadCapsule(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, nil)
+	rb := NewRigidBody(NewRigidBodyConfig())
+	sc := NewShapeConfig()
+	sc.Geometry = NewCapsuleGeometry(0.3, 0.6)
+	rb.AddShape(NewShape(sc))
+	w.AddRigidBody(rb)
+	w.Step(1.0 / 60)
+
+	var encoded bytes.Buffer
+	if err := SaveWorld(&encoded, w, SceneFormat_JSON); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadWorld(&encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capsule, ok := loaded.GetRigidBodyList().GetShapeList().geom.(*CapsuleGeometry)
+	if !ok || capsule.GetRadius() != 0.3 || capsule.GetHalfHeight() != 0.6 {
+		t.Fatalf("loaded the geometry %#v", loaded.GetRigidBodyList().GetShapeList().geom)
+	}
+	if loaded.Checksum() != w.Checksum() {
+		t.Fatal("the loaded world differs")
+	}
+}
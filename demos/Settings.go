@@ -37,6 +37,8 @@ type SettingsStruct struct {
 	AlternativeContactPositionCorrectionAlgorithm                  PositionCorrectionAlgorithm
 	ContactPersistenceThreshold                                    float64
 	MaxManifoldPoints                                              int
+	ContactSlidingVelocityThreshold                                float64
+	OneWayContactVelocityThreshold                                 float64
 
 	// joints
 	DefaultJointConstraintSolverType        ConstraintSolverType
@@ -99,6 +101,8 @@ var Settings = SettingsStruct{
 	AlternativeContactPositionCorrectionAlgorithm:                  PositionCorrectionAlgorithm_SPLIT_IMPULSE,
 	ContactPersistenceThreshold:                                    0.05,
 	MaxManifoldPoints:                                              4,
+	ContactSlidingVelocityThreshold:                                0.01, // dynamic friction is used above this speed
+	OneWayContactVelocityThreshold:                                 0.01, // one-way contacts moving out of the back side faster than this are ignored
 
 	// joints
 	DefaultJointConstraintSolverType:        ConstraintSolverType_ITERATIVE,
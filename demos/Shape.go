@@ -15,9 +15,18 @@ type Shape struct {
 	pTransform     Transform
 	transform      Transform
 
-	restitution float64
-	friction    float64
-	density     float64
+	material *Material
+	density  float64
+
+	frictionDirection Vec3
+	frictionScale1    float64
+	frictionScale2    float64
+	surfaceVelocity   Vec3
+
+	contactStiffness float64
+	contactDamping   float64
+
+	oneWayNormal Vec3
 
 	aabb Aabb
 
@@ -28,6 +37,9 @@ type Shape struct {
 
 	contactCallback IContactCallback
 
+	trigger         bool
+	triggerCallback ITriggerCallback
+
 	displacement Vec3
 
 	userData any // Extra field that users can use for their own purposes.
@@ -35,15 +47,26 @@ type Shape struct {
 
 func NewShape(config *ShapeConfig) *Shape {
 	s := &Shape{
-		id:              -1,
-		restitution:     config.Restitution,
-		friction:        config.Friction,
-		density:         config.Density,
-		geom:            config.Geometry,
-		localTransform:  Transform{config.Position, config.Rotation},
-		collisionGroup:  config.CollisionGroup,
-		collisionMask:   config.CollisionMask,
-		contactCallback: config.ContactCallback,
+		id:                -1,
+		material:          config.Material,
+		frictionDirection: config.FrictionDirection,
+		frictionScale1:    config.FrictionScale1,
+		frictionScale2:    config.FrictionScale2,
+		surfaceVelocity:   config.SurfaceVelocity,
+		density:           config.Density,
+		contactStiffness:  config.ContactStiffness,
+		contactDamping:    config.ContactDamping,
+		oneWayNormal:      config.OneWayNormal,
+		geom:              config.Geometry,
+		localTransform:    Transform{config.Position, config.Rotation},
+		collisionGroup:    config.CollisionGroup,
+		collisionMask:     config.CollisionMask,
+		contactCallback:   config.ContactCallback,
+		trigger:           config.Trigger,
+		triggerCallback:   config.TriggerCallback,
+	}
+	if s.material == nil {
+		s.material = NewMaterial().Init(config.Friction, config.Friction, config.Restitution)
 	}
 	s.pTransform = s.localTransform
 	s.transform = s.localTransform
@@ -85,3 +108,94 @@ func (sh *Shape) sync(tf1 *Transform, tf2 *Transform) {
 		sh.rigidBody.world.broadPhase.MoveProxy(sh.proxy, &sh.aabb, sh.displacement)
 	}
 }
+
+// --- public ---
+
+// Returns the parent rigid body. This returns `nil` if the shape doesn't belong to any rigid body.
+func (sh *Shape) GetRigidBody() *RigidBody {
+	return sh.rigidBody
+}
+
+// Returns the stiffness of the contacts of the shape. See `ShapeConfig.ContactStiffness` for details.
+func (sh *Shape) GetContactStiffness() float64 {
+	return sh.contactStiffness
+}
+
+// Sets the stiffness of the contacts of the shape to `stiffness`. See `ShapeConfig.ContactStiffness` for details.
+func (sh *Shape) SetContactStiffness(stiffness float64) {
+	sh.contactStiffness = stiffness
+}
+
+// Returns the damping of the contacts of the shape. See `ShapeConfig.ContactDamping` for details.
+func (sh *Shape) GetContactDamping() float64 {
+	return sh.contactDamping
+}
+
+// Sets the damping of the contacts of the shape to `damping`. See `ShapeConfig.ContactDamping` for details.
+func (sh *Shape) SetContactDamping(damping float64) {
+	sh.contactDamping = damping
+}
+
+// Returns the material of the shape.
+func (sh *Shape) GetMaterial() *Material {
+	return sh.material
+}
+
+// Sets the material of the shape to `material`.
+func (sh *Shape) SetMaterial(material *Material) {
+	sh.material = material
+}
+
+// Returns the direction of anisotropic friction in the shape's local frame. See `ShapeConfig.FrictionDirection`.
+func (sh *Shape) GetFrictionDirection() Vec3 {
+	return sh.frictionDirection
+}
+
+// Returns the scales of the coefficient of friction along and across the friction direction.
+func (sh *Shape) GetFrictionScales() (scale1, scale2 float64) {
+	return sh.frictionScale1, sh.frictionScale2
+}
+
+// Sets the direction of anisotropic friction in the shape's local frame to `direction`, and the scales of the
+// coefficient of friction along and across it to `scale1` and `scale2`. Set a zero direction for isotropic friction.
+func (sh *Shape) SetFrictionDirection(direction Vec3, scale1, scale2 float64) {
+	sh.frictionDirection = direction
+	sh.frictionScale1 = scale1
+	sh.frictionScale2 = scale2
+}
+
+// Returns the velocity of the surface in the shape's local frame. See `ShapeConfig.SurfaceVelocity`.
+func (sh *Shape) GetSurfaceVelocity() Vec3 {
+	return sh.surfaceVelocity
+}
+
+// Sets the velocity of the surface in the shape's local frame to `surfaceVelocity`.
+func (sh *Shape) SetSurfaceVelocity(surfaceVelocity Vec3) {
+	sh.surfaceVelocity = surfaceVelocity
+}
+
+// Returns the one-way normal of the shape in the shape's local frame. See `ShapeConfig.OneWayNormal` for details.
+func (sh *Shape) GetOneWayNormal() Vec3 {
+	return sh.oneWayNormal
+}
+
+// Sets the one-way normal of the shape in the shape's local frame to `oneWayNormal`. Set a zero vector to make the
+// shape collide from all sides. See `ShapeConfig.OneWayNormal` for details.
+func (sh *Shape) SetOneWayNormal(oneWayNormal Vec3) {
+	sh.oneWayNormal = oneWayNormal
+}
+
+// Returns whether the shape is a trigger. See `ShapeConfig.Trigger` for details.
+func (sh *Shape) IsTrigger() bool {
+	return sh.trigger
+}
+
+// Returns the trigger callback of the shape.
+func (sh *Shape) GetTriggerCallback() ITriggerCallback {
+	return sh.triggerCallback
+}
+
+// Sets the trigger callback of the shape to `triggerCallback`.
+func (sh *Shape) SetTriggerCallback(triggerCallback ITriggerCallback) {
+	sh.triggerCallback = triggerCallback
+}
@@ -7,11 +7,38 @@ package demos
 type ShapeConfig struct {
 	Position    Vec3      // The shape's local position relative to the parent rigid body's origin.
 	Rotation    Mat3      // The shape's local rotation matrix relative to the parent rigid body's rotation.
-	Friction    float64   // The coefficient of friction of the shape.
-	Restitution float64   // The coefficient of restitution of the shape.
+	Friction    float64   // The coefficient of friction of the shape. Ignored if `Material` is set.
+	Restitution float64   // The coefficient of restitution of the shape. Ignored if `Material` is set.
 	Density     float64   // The density of the shape, usually in Kg/m^3.
 	Geometry    IGeometry // The collision geometry of the shape.
 
+	// The material of the shape. If this is `nil`, the shape gets its own material whose static and dynamic
+	// coefficients of friction are `Friction`, and whose coefficient of restitution is `Restitution`.
+	Material *Material
+
+	// The direction of anisotropic friction in the shape's local frame. If this is not zero, the coefficient of
+	// friction is multiplied by `FrictionScale1` along this direction, and by `FrictionScale2` across it. If both
+	// shapes of a contact set a direction, the direction and the scales of the first shape are used.
+	FrictionDirection Vec3
+	FrictionScale1    float64
+	FrictionScale2    float64
+
+	// The velocity of the surface of the shape in the shape's local frame, relative to the motion of the shape.
+	// Friction drags touching bodies along it, as a conveyor belt does.
+	SurfaceVelocity Vec3
+
+	// The stiffness of the contacts of the shape in newtons per meter per contact point. The contacts are soft and
+	// let the shapes sink into each other if either this or `ContactDamping` is positive, and rigid otherwise.
+	ContactStiffness float64
+
+	// The damping of the contacts of the shape in newton seconds per meter per contact point. See `ContactStiffness`.
+	ContactDamping float64
+
+	// The one-way normal of the shape in the shape's local frame. If this is not zero, the shape collides only with
+	// shapes that touch it from the side the normal points to, like a platform that can be jumped through from below.
+	// Whether a contact is ignored is decided when the shapes start touching, and kept until they stop touching.
+	OneWayNormal Vec3
+
 	// The collision group bits the shape belongs to. This is used for collision filtering.
 	// Two shapes `shape1` and `shape2` will collide only if both
 	// `shape1.collisionGroup & shape2.collisionMask` and
@@ -26,6 +53,14 @@ type ShapeConfig struct {
 
 	// The contact callback of the shape. The callback methods are called when contact events the shape is involved occurred.
 	ContactCallback IContactCallback
+
+	// Whether the shape is a trigger. A trigger shape reports overlaps with other shapes through `TriggerCallback`, but
+	// never collides with them. Trigger shapes detect static and sleeping rigid bodies too, but not other trigger shapes.
+	Trigger bool
+
+	// The trigger callback of the shape. The callback methods are called when other shapes enter, stay in and exit the
+	// shape, if the shape is a trigger.
+	TriggerCallback ITriggerCallback
 }
 
 func NewShapeConfig() *ShapeConfig {
@@ -33,6 +68,8 @@ func NewShapeConfig() *ShapeConfig {
 		Friction:       Settings.DefaultFriction,
 		Restitution:    Settings.DefaultRestitution,
 		Density:        Settings.DefaultDensity,
+		FrictionScale1: 1,
+		FrictionScale2: 1,
 		CollisionGroup: Settings.DefaultCollisionGroup,
 		CollisionMask:  Settings.DefaultCollisionMask,
 	}
@@ -11,7 +11,7 @@ var SimplexUtil = SimplexUtilNamespace{}
 // Sets `out` to the minimum length point on the line (`vec1`, `vec2`) and returns the index of the voronoi region.
 func (SimplexUtilNamespace) projectOrigin2(v1 Vec3, v2 Vec3, out *Vec3) int {
 	var v12 Vec3
-	MathUtil.Vec3_sub(&v12, &v1, &v2)
+	MathUtil.Vec3_sub(&v12, &v2, &v1)
 
 	d := v12.Dot(v12)
 	t := v12.Dot(v1)
@@ -0,0 +1,197 @@
+package demos
+
+//////////////////////////////////////////////// SpatialAlgebra
+// (goimo)
+// Internal 6D spatial vectors and matrices used by the articulated-body algorithm.
+// All the quantities are expressed in the world frame, about the reference point of the articulation.
+
+// A spatial motion or force vector. For motions `w` is the angular and `v` the linear part, for forces `w` is the
+// moment and `v` the force.
+type spatialVec struct {
+	w Vec3
+	v Vec3
+}
+
+func (s spatialVec) add(o spatialVec) spatialVec {
+	return spatialVec{s.w.Add(o.w), s.v.Add(o.v)}
+}
+
+func (s spatialVec) sub(o spatialVec) spatialVec {
+	return spatialVec{s.w.Sub(o.w), s.v.Sub(o.v)}
+}
+
+func (s spatialVec) scale(k float64) spatialVec {
+	return spatialVec{s.w.Scale(k), s.v.Scale(k)}
+}
+
+func (s spatialVec) negate() spatialVec {
+	return spatialVec{s.w.Negate(), s.v.Negate()}
+}
+
+// Returns the spatial cross product of the motion `s` and the motion `o`.
+func (s spatialVec) crossMotion(o spatialVec) spatialVec {
+	v := s.w.Cross(o.v)
+	return spatialVec{s.w.Cross(o.w), v.Add(s.v.Cross(o.w))}
+}
+
+// Returns the spatial cross product of the motion `s` and the force `f`.
+func (s spatialVec) crossForce(f spatialVec) spatialVec {
+	w := s.w.Cross(f.w)
+	return spatialVec{w.Add(s.v.Cross(f.v)), s.w.Cross(f.v)}
+}
+
+// Returns the linear velocity at `point` of the motion `s`.
+func (s spatialVec) pointVelocity(point Vec3) Vec3 {
+	return s.v.Add(s.w.Cross(point))
+}
+
+// A spatial 6x6 matrix made of four 3x3 blocks:
+// [ a b ]
+// [ c d ]
+type spatialMat struct {
+	a, b, c, d Mat3
+}
+
+// Sets the spatial inertia of a body of mass `mass`, the world inertia tensor `inertia` about its center of mass, and
+// the center of mass `com`.
+func (m *spatialMat) setInertia(mass float64, inertia *Mat3, com Vec3) {
+	cx := _mat3Skew(com)
+	var cxcx Mat3
+	MathUtil.Mat3_mul(&cxcx, &cx, &cx)
+	MathUtil.Mat3_addRhsScaled(&m.a, inertia, &cxcx, -mass)
+	MathUtil.Mat3_scale(&m.b, &cx, mass)
+	MathUtil.Mat3_scale(&m.c, &cx, -mass)
+	MathUtil.Mat3_diagonal(&m.d, mass, mass, mass)
+}
+
+func (m *spatialMat) mulVec(s spatialVec) spatialVec {
+	w := s.w.MulMat3(&m.a)
+	v := s.w.MulMat3(&m.c)
+	return spatialVec{w.Add(s.v.MulMat3(&m.b)), v.Add(s.v.MulMat3(&m.d))}
+}
+
+func (m *spatialMat) addEq(o *spatialMat) {
+	MathUtil.Mat3_add(&m.a, &m.a, &o.a)
+	MathUtil.Mat3_add(&m.b, &m.b, &o.b)
+	MathUtil.Mat3_add(&m.c, &m.c, &o.c)
+	MathUtil.Mat3_add(&m.d, &m.d, &o.d)
+}
+
+// Solves `m * x = s` for `x` using the Schur complement of the lower right block.
+func (m *spatialMat) solve(s spatialVec) spatialVec {
+	var dInv, bdInv, schur, schurInv Mat3
+	MathUtil.Mat3_inv(&dInv, &m.d)
+	MathUtil.Mat3_mul(&bdInv, &m.b, &dInv)
+	MathUtil.Mat3_mul(&schur, &bdInv, &m.c)
+	MathUtil.Mat3_addRhsScaled(&schur, &m.a, &schur, -1)
+	MathUtil.Mat3_inv(&schurInv, &schur)
+
+	w := s.w.Sub(s.v.MulMat3(&bdInv))
+	w = w.MulMat3(&schurInv)
+	v := s.v.Sub(w.MulMat3(&m.c))
+	v = v.MulMat3(&dInv)
+	return spatialVec{w, v}
+}
+
+// A spatial 6x3 matrix made of two 3x3 blocks, used for joint motion subspaces.
+type spatialSubspace struct {
+	w, v Mat3
+}
+
+// Returns `m * x`.
+func (m *spatialSubspace) mulVec(x Vec3) spatialVec {
+	return spatialVec{x.MulMat3(&m.w), x.MulMat3(&m.v)}
+}
+
+// Returns `m^T * s`.
+func (m *spatialSubspace) mulVecTransposed(s spatialVec) Vec3 {
+	x := s.w.MulMat3Transposed(&m.w)
+	return x.Add(s.v.MulMat3Transposed(&m.v))
+}
+
+// Sets `dst` to `m * s`.
+func (m *spatialMat) mulSubspace(dst *spatialSubspace, s *spatialSubspace) {
+	var t Mat3
+	MathUtil.Mat3_mul(&dst.w, &m.a, &s.w)
+	MathUtil.Mat3_mul(&t, &m.b, &s.v)
+	MathUtil.Mat3_add(&dst.w, &dst.w, &t)
+	MathUtil.Mat3_mul(&dst.v, &m.c, &s.w)
+	MathUtil.Mat3_mul(&t, &m.d, &s.v)
+	MathUtil.Mat3_add(&dst.v, &dst.v, &t)
+}
+
+// Subtracts `u * k * u^T` from `m`.
+func (m *spatialMat) subCongruence(u *spatialSubspace, k *Mat3) {
+	var uk, t Mat3
+	MathUtil.Mat3_mul(&uk, &u.w, k)
+	MathUtil.Mat3_mulRhsTransposed(&t, &uk, &u.w)
+	MathUtil.Mat3_addRhsScaled(&m.a, &m.a, &t, -1)
+	MathUtil.Mat3_mulRhsTransposed(&t, &uk, &u.v)
+	MathUtil.Mat3_addRhsScaled(&m.b, &m.b, &t, -1)
+	MathUtil.Mat3_mul(&uk, &u.v, k)
+	MathUtil.Mat3_mulRhsTransposed(&t, &uk, &u.w)
+	MathUtil.Mat3_addRhsScaled(&m.c, &m.c, &t, -1)
+	MathUtil.Mat3_mulRhsTransposed(&t, &uk, &u.v)
+	MathUtil.Mat3_addRhsScaled(&m.d, &m.d, &t, -1)
+}
+
+// Returns `s1^T * s2`.
+func _subspaceInner(s1, s2 *spatialSubspace) Mat3 {
+	tw := _mat3Transposed(&s1.w)
+	tv := _mat3Transposed(&s1.v)
+	var a, b Mat3
+	MathUtil.Mat3_mul(&a, &tw, &s2.w)
+	MathUtil.Mat3_mul(&b, &tv, &s2.v)
+	MathUtil.Mat3_add(&a, &a, &b)
+	return a
+}
+
+// Returns the matrix `[v]x` such that `[v]x * u = v x u`.
+func _mat3Skew(v Vec3) Mat3 {
+	return Mat3{
+		0, -v.z, v.y,
+		v.z, 0, -v.x,
+		-v.y, v.x, 0,
+	}
+}
+
+func _mat3Transposed(m *Mat3) Mat3 {
+	return Mat3{
+		m.e00, m.e10, m.e20,
+		m.e01, m.e11, m.e21,
+		m.e02, m.e12, m.e22,
+	}
+}
+
+// Returns the rotation vector of the rotation from `from` to `to`.
+func _rotationVector(from, to *Mat3) Vec3 {
+	var rel Mat3
+	MathUtil.Mat3_mulRhsTransposed(&rel, to, from)
+	var q Quat
+	MathUtil.Quat_fromMat3(&q, &rel)
+	if q.w < 0 {
+		q.x, q.y, q.z, q.w = -q.x, -q.y, -q.z, -q.w
+	}
+	v := Vec3{q.x, q.y, q.z}
+	sinHalf := v.Length()
+	if sinHalf < 1e-9 {
+		return v.Scale(2)
+	}
+	return v.Scale(2 * MathUtil.Atan2(sinHalf, q.w) / sinHalf)
+}
+
+// Returns the rotation matrix of the rotation vector `v`.
+func _rotationFromVector(v Vec3) Mat3 {
+	theta := v.Length()
+	var q Quat
+	if theta < 1e-9 {
+		q = Quat{v.x * 0.5, v.y * 0.5, v.z * 0.5, 1}
+	} else {
+		s := MathUtil.Sin(theta*0.5) / theta
+		q = Quat{v.x * s, v.y * s, v.z * s, MathUtil.Cos(theta * 0.5)}
+	}
+	MathUtil.Quat_normalize(&q, &q)
+	var m Mat3
+	MathUtil.Mat3_fromQuat(&m, &q)
+	return m
+}
@@ -0,0 +1,44 @@
+package demos
+
+//////////////////////////////////////////////// SpringDamper
+// (oimo/dynamics/constraint/joint/SpringDamper.go)
+// Spring and damper settings of a joint.
+
+type SpringDamper struct {
+	// The frequency of the spring in Hz.
+	// Set `0.0` to disable the spring and make the constraint totally rigid.
+	Frequency float64
+
+	// The damping ratio of the constraint.
+	// Set `1.0` to make the constraint critically damped.
+	DampingRatio float64
+
+	// Whether to use symplectic Euler method instead of implicit Euler method, to numarically integrate the constraint.
+	// Note that symplectic Euler method conserves energy better than implicit Euler method does, but the constraint will be
+	// unstable under the high frequency.
+	UseSymplecticEuler bool
+}
+
+func NewSpringDamper() *SpringDamper {
+	return &SpringDamper{}
+}
+
+// Sets spring and damper parameters at once and returns `this`.
+// `this.frequency` is set to `frequency`, and `this.dampingRatio` is set to `dampingRatio`.
+func (self *SpringDamper) SetSpring(frequency, dampingRatio float64) *SpringDamper {
+	self.Frequency = frequency
+	self.DampingRatio = dampingRatio
+	return self
+}
+
+// Sets whether to use symplectic Euler method and returns `this`.
+func (self *SpringDamper) SetSymplecticEuler(useSymplecticEuler bool) *SpringDamper {
+	self.UseSymplecticEuler = useSymplecticEuler
+	return self
+}
+
+// Returns a clone of the object
+func (self *SpringDamper) Clone() *SpringDamper {
+	sd := *self
+	return &sd
+}
@@ -8,6 +8,9 @@ type TimeStep struct {
 	Dt      float64
 	InvDt   float64
 	DtRatio float64
+
+	// index of the sub-step in the step, always 0 without sub-stepping
+	subStep int
 }
 
 func NewTimeStep() *TimeStep {
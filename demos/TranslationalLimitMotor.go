@@ -0,0 +1,72 @@
+package demos
+
+//////////////////////////////////////////////// TranslationalLimitMotor
+// (oimo/dynamics/constraint/joint/TranslationalLimitMotor.go)
+// Translational limits and motor settings of a joint.
+
+type TranslationalLimitMotor struct {
+	// The lower bound of the limit in meters.
+	// The limit will be disabled if `lowerLimit > upperLimit`.
+	LowerLimit float64
+
+	// The upper bound of the limit in meters.
+	// The limit will be disabled if `lowerLimit > upperLimit`.
+	UpperLimit float64
+
+	// The target speed of the motor in meters per second.
+	MotorSpeed float64
+
+	// The maximum force of the motor in newtons.
+	// The motor will be disabled if `motorForce <= 0`.
+	MotorForce float64
+
+	// The target position of the servo in meters. The servo pulls the translation toward this, and damps the speed
+	// toward `motorSpeed`, using the motor with the maximum force of `motorForce`.
+	ServoTarget float64
+
+	// The stiffness of the servo in newtons per meter.
+	// The servo will be disabled if both `servoStiffness` and `servoDamping` are `0`.
+	ServoStiffness float64
+
+	// The damping of the servo in newton seconds per meter.
+	ServoDamping float64
+}
+
+func NewTranslationalLimitMotor() *TranslationalLimitMotor {
+	return &TranslationalLimitMotor{
+		LowerLimit: 1,
+		UpperLimit: 0,
+	}
+}
+
+// Sets limit properties at once and returns `this`.
+// `this.lowerLimit` is set to `lower`, and `this.upperLimit` is set to `upper`.
+func (self *TranslationalLimitMotor) SetLimits(lower, upper float64) *TranslationalLimitMotor {
+	self.LowerLimit = lower
+	self.UpperLimit = upper
+	return self
+}
+
+// Sets motor properties at once and returns `this`.
+// `this.motorSpeed` is set to `speed`, and `this.motorForce` is set to `force`.
+func (self *TranslationalLimitMotor) SetMotor(speed, force float64) *TranslationalLimitMotor {
+	self.MotorSpeed = speed
+	self.MotorForce = force
+	return self
+}
+
+// Sets servo properties at once and returns `this`.
+// `this.servoTarget` is set to `target`, `this.servoStiffness` is set to `stiffness`, and `this.servoDamping` is set
+// to `damping`.
+func (self *TranslationalLimitMotor) SetServo(target, stiffness, damping float64) *TranslationalLimitMotor {
+	self.ServoTarget = target
+	self.ServoStiffness = stiffness
+	self.ServoDamping = damping
+	return self
+}
+
+// Returns a clone of the object
+func (self *TranslationalLimitMotor) Clone() *TranslationalLimitMotor {
+	lm := *self
+	return &lm
+}
@@ -0,0 +1,37 @@
+package demos
+
+///////////////////////////////// TriggerCallback
+// (goimo)
+// A callback class for trigger events. A trigger shape reports overlaps with other shapes but never collides with
+// them, see `ShapeConfig.Trigger`. Trigger events between a trigger shape and another shape will occur in following
+// order:
+//
+// 1. `TriggerEnter`
+// 2. `TriggerStay` (repeats every step while the shapes are overlapping)
+// 3. `TriggerExit`
+
+type ITriggerCallback interface {
+	// This is called when the shape `other` starts overlapping the trigger shape `trigger`.
+	TriggerEnter(trigger, other *Shape)
+	// This is called every step after `TriggerEnter` while the shape `other` is overlapping the trigger shape `trigger`.
+	TriggerStay(trigger, other *Shape)
+	// This is called when the shape `other` ends overlapping the trigger shape `trigger`, or either of them is removed
+	// from the world.
+	TriggerExit(trigger, other *Shape)
+}
+
+// A trigger callback whose methods do nothing. Embed this to implement only some of the methods of `ITriggerCallback`.
+type TriggerCallback struct{}
+
+func NewTriggerCallback() *TriggerCallback {
+	return &TriggerCallback{}
+}
+
+func (tc *TriggerCallback) TriggerEnter(trigger, other *Shape) {
+}
+
+func (tc *TriggerCallback) TriggerStay(trigger, other *Shape) {
+}
+
+func (tc *TriggerCallback) TriggerExit(trigger, other *Shape) {
+}
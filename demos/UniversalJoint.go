@@ -0,0 +1,173 @@
+package demos
+
+//////////////////////////////////////////////// UniversalJoint
+// (oimo/dynamics/constraint/joint/UniversalJoint.go)
+// A universal joint constrains two rigid bodies' constraint axes to be perpendicular to each other. Rigid bodies can
+// rotate along their constraint axes, but cannot along the direction perpendicular to two constraint axes. This joint
+// provides two degrees of freedom. You can enable lower and upper limits, motors, spring and damper effects of the two
+// rotational constraints.
+
+type UniversalJoint struct {
+	*Joint
+
+	sd1 *SpringDamper
+	sd2 *SpringDamper
+	lm1 *RotationalLimitMotor
+	lm2 *RotationalLimitMotor
+
+	// relative rotation as XYZ euler angles, Y is the error
+	angleX float64
+	angleY float64
+	angleZ float64
+
+	// jacobian axes of the euler angles
+	axisX Vec3
+	axisY Vec3
+	axisZ Vec3
+}
+
+// Creates a new universal joint by configuration `config`.
+func NewUniversalJoint(config *UniversalJointConfig) *UniversalJoint {
+	j := &UniversalJoint{
+		Joint: NewJoint(config.JointConfig, JointType_UNIVERSAL),
+		sd1:   config.SpringDamper1.Clone(),
+		sd2:   config.SpringDamper2.Clone(),
+		lm1:   config.LimitMotor1.Clone(),
+		lm2:   config.LimitMotor2.Clone(),
+	}
+	j.impl = j
+
+	j.localBasisX1 = config.LocalAxis1
+	j.localBasisZ2 = config.LocalAxis2
+	j.buildLocalBasesFromX1Z2()
+
+	return j
+}
+
+func (self *UniversalJoint) getInfo(info *JointSolverInfo, timeStep TimeStep, isPositionPart bool) {
+	// compute ERP
+	erp := self.getErp(timeStep, isPositionPart)
+
+	// linear
+	self.addSphericalRows(info, erp)
+
+	// angular X
+	if self.sd1.Frequency <= 0 || !isPositionPart {
+		row := info.AddRow(&self.impulses[3])
+		self.setSolverInfoRowAngular(row, self.angleX, self.lm1, self.computeEffectiveInertiaMoment(self.axisX), self.sd1, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.axisX)
+	}
+
+	// angular Y
+	row := info.AddRow(&self.impulses[4])
+	row.EqualLimit(self.angleY*erp, 0)
+	self.setJacobianAngular(row.jacobian, self.axisY)
+
+	// angular Z
+	if self.sd2.Frequency <= 0 || !isPositionPart {
+		row = info.AddRow(&self.impulses[5])
+		self.setSolverInfoRowAngular(row, self.angleZ, self.lm2, self.computeEffectiveInertiaMoment(self.axisZ), self.sd2, timeStep, isPositionPart)
+		self.setJacobianAngular(row.jacobian, self.axisZ)
+	}
+}
+
+func (self *UniversalJoint) computeErrors() {
+	var angles Vec3
+	angles, self.axisX, self.axisY, self.axisZ = self.computeEulerAngles()
+	self.angleX = angles.x
+	self.angleY = angles.y
+	self.angleZ = angles.z
+}
+
+// --- internal ---
+
+func (self *UniversalJoint) syncAnchors() { // override
+	self.Joint.syncAnchors()
+	self.computeErrors()
+}
+
+func (self *UniversalJoint) getVelocitySolverInfo(timeStep TimeStep, info *JointSolverInfo) { // override
+	self.Joint.getVelocitySolverInfo(timeStep, info)
+	self.getInfo(info, timeStep, false)
+}
+
+func (self *UniversalJoint) getPositionSolverInfo(info *JointSolverInfo) { // override
+	self.Joint.getPositionSolverInfo(info)
+	self.getInfo(info, TimeStep{}, true)
+}
+
+// --- public ---
+
+// Returns the first rigid body's constraint axis in world coordinates.
+func (self *UniversalJoint) GetAxis1() Vec3 {
+	return self.basisX1
+}
+
+// Returns the second rigid body's constraint axis in world coordinates.
+func (self *UniversalJoint) GetAxis2() Vec3 {
+	return self.basisZ2
+}
+
+// Sets `axis` to the first rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *UniversalJoint) GetAxis1To(axis *Vec3) {
+	*axis = self.basisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis in world coordinates.
+// This does not create a new instance of `Vec3`.
+func (self *UniversalJoint) GetAxis2To(axis *Vec3) {
+	*axis = self.basisZ2
+}
+
+// Returns the first rigid body's constraint axis relative to the rigid body's transform.
+func (self *UniversalJoint) GetLocalAxis1() Vec3 {
+	return self.localBasisX1
+}
+
+// Returns the second rigid body's constraint axis relative to the rigid body's transform.
+func (self *UniversalJoint) GetLocalAxis2() Vec3 {
+	return self.localBasisZ2
+}
+
+// Sets `axis` to the first rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *UniversalJoint) GetLocalAxis1To(axis *Vec3) {
+	*axis = self.localBasisX1
+}
+
+// Sets `axis` to the second rigid body's constraint axis relative to the rigid body's transform.
+// This does not create a new instance of `Vec3`.
+func (self *UniversalJoint) GetLocalAxis2To(axis *Vec3) {
+	*axis = self.localBasisZ2
+}
+
+// Returns the rotational spring and damper settings along the first body's constraint axis.
+func (self *UniversalJoint) GetSpringDamper1() *SpringDamper {
+	return self.sd1
+}
+
+// Returns the rotational spring and damper settings along the second body's constraint axis.
+func (self *UniversalJoint) GetSpringDamper2() *SpringDamper {
+	return self.sd2
+}
+
+// Returns the rotational limits and motor settings along the first body's constraint axis.
+func (self *UniversalJoint) GetLimitMotor1() *RotationalLimitMotor {
+	return self.lm1
+}
+
+// Returns the rotational limits and motor settings along the second body's constraint axis.
+func (self *UniversalJoint) GetLimitMotor2() *RotationalLimitMotor {
+	return self.lm2
+}
+
+// Returns the rotation angle along the first body's constraint axis.
+func (self *UniversalJoint) GetAngle1() float64 {
+	return self.angleX
+}
+
+// Returns the rotation angle along the second body's constraint axis.
+func (self *UniversalJoint) GetAngle2() float64 {
+	return self.angleZ
+}
@@ -0,0 +1,48 @@
+package demos
+
+//////////////////////////////////////////////// UniversalJointConfig
+// (oimo/dynamics/constraint/joint/UniversalJointConfig.go)
+// A universal joint config is used for constructions of universal joints.
+
+type UniversalJointConfig struct {
+	*JointConfig
+
+	// The first body's local constraint axis.
+	LocalAxis1 Vec3
+
+	// The second body's local constraint axis.
+	LocalAxis2 Vec3
+
+	// The rotational spring and damper along the first body's constraint axis.
+	SpringDamper1 *SpringDamper
+
+	// The rotational spring and damper along the second body's constraint axis.
+	SpringDamper2 *SpringDamper
+
+	// The rotational limit and motor along the first body's constraint axis.
+	LimitMotor1 *RotationalLimitMotor
+
+	// The rotational limit and motor along the second body's constraint axis.
+	LimitMotor2 *RotationalLimitMotor
+}
+
+func NewUniversalJointConfig() *UniversalJointConfig {
+	return &UniversalJointConfig{
+		JointConfig:   NewJointConfig(),
+		LocalAxis1:    Vec3{1, 0, 0},
+		LocalAxis2:    Vec3{0, 0, 1},
+		SpringDamper1: NewSpringDamper(),
+		SpringDamper2: NewSpringDamper(),
+		LimitMotor1:   NewRotationalLimitMotor(),
+		LimitMotor2:   NewRotationalLimitMotor(),
+	}
+}
+
+// Sets rigid bodies, local anchors from the world anchor `worldAnchor`, local axes from the world axes `worldAxis1` and
+// `worldAxis2`, and returns `this`.
+func (self *UniversalJointConfig) Init(rigidBody1, rigidBody2 *RigidBody, worldAnchor, worldAxis1, worldAxis2 Vec3) *UniversalJointConfig {
+	self.init(rigidBody1, rigidBody2, worldAnchor)
+	rigidBody1.GetLocalVectorTo(worldAxis1, &self.LocalAxis1)
+	rigidBody2.GetLocalVectorTo(worldAxis2, &self.LocalAxis2)
+	return self
+}
@@ -0,0 +1,27 @@
+package demos
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUniversalJointLimits(t *testing.T) {
+	w, base, box := jointTestWorld()
+	config := NewUniversalJointConfig().Init(base, box, Vec3{}, Vec3{1, 0, 0}, Vec3{0, 0, 1})
+	config.LimitMotor1.SetLimits(-0.3, 0.3).SetMotor(2, 100)
+	config.LimitMotor2.SetLimits(-0.2, 0.2).SetMotor(-2, 100)
+	j := NewUniversalJoint(config)
+	w.AddJoint(j.Joint)
+
+	// a limit only engages once crossed, so the joint may overshoot it by a step at the motor speed
+	maxOvershoot := 2.0/60 + Settings.AngularSlop
+	for range 90 {
+		stepWorld(w, 1)
+		if a1, a2 := j.GetAngle1(), j.GetAngle2(); a1 > 0.3+maxOvershoot || a2 < -0.2-maxOvershoot {
+			t.Fatalf("rotated past the limits: %v, %v", a1, a2)
+		}
+	}
+	if a1, a2 := j.GetAngle1(), j.GetAngle2(); math.Abs(a1-0.3) > Settings.AngularSlop*2 || math.Abs(a2+0.2) > Settings.AngularSlop*2 {
+		t.Fatalf("stopped at %v, %v", a1, a2)
+	}
+}
@@ -0,0 +1,179 @@
+package demos
+
+//////////////////////////////////////////////// VehicleWheel
+// (goimo)
+// A wheel of a raycast vehicle. The wheel is a ray cast down from its suspension point every update, and holds the
+// driver's input and the contact state of the last update.
+
+type VehicleWheel struct {
+	vehicle *RaycastVehicle
+
+	position           Vec3
+	radius             float64
+	restLength         float64
+	maxTravel          float64
+	stiffness          float64
+	compressionDamping float64
+	relaxationDamping  float64
+	frictionSlip       float64
+
+	steering    float64
+	engineForce float64
+	brake       float64
+
+	inContact       bool
+	contactPosition Vec3
+	contactNormal   Vec3
+	contactShape    *Shape
+
+	suspensionLength float64
+	suspensionForce  float64
+	slip             float64
+
+	// the rolling angle and speed about the axle
+	rotation  float64
+	spinSpeed float64
+}
+
+func newVehicleWheel(vehicle *RaycastVehicle, config *VehicleWheelConfig) *VehicleWheel {
+	return &VehicleWheel{
+		vehicle:            vehicle,
+		position:           config.Position,
+		radius:             config.Radius,
+		restLength:         config.SuspensionRestLength,
+		maxTravel:          config.SuspensionMaxTravel,
+		stiffness:          config.SuspensionStiffness,
+		compressionDamping: config.SuspensionCompressionDamping,
+		relaxationDamping:  config.SuspensionRelaxationDamping,
+		frictionSlip:       config.FrictionSlip,
+		suspensionLength:   config.SuspensionRestLength + config.SuspensionMaxTravel,
+	}
+}
+
+// --- internal ---
+
+// returns the rolling direction of the steered wheel in the local coordinates of the chassis
+func (self *VehicleWheel) localForward() Vec3 {
+	up := self.vehicle.up
+	forward := self.vehicle.forward
+	left := up.Cross(forward)
+	forward.ScaleEq(MathUtil.Cos(self.steering))
+	return forward.AddScaled(left, MathUtil.Sin(self.steering))
+}
+
+// --- public ---
+
+// Returns the steering angle in radians. Positive angles turn the wheel to the left.
+func (self *VehicleWheel) GetSteering() float64 {
+	return self.steering
+}
+
+// Sets the steering angle to `steering` in radians. Positive angles turn the wheel to the left.
+func (self *VehicleWheel) SetSteering(steering float64) {
+	self.steering = steering
+	self.vehicle.chassis.WakeUp()
+}
+
+// Returns the force the engine drives the wheel with.
+func (self *VehicleWheel) GetEngineForce() float64 {
+	return self.engineForce
+}
+
+// Sets the force the engine drives the wheel with to `engineForce`. Negative forces drive backward.
+func (self *VehicleWheel) SetEngineForce(engineForce float64) {
+	self.engineForce = engineForce
+	self.vehicle.chassis.WakeUp()
+}
+
+// Returns the largest force the brake stops the wheel with.
+func (self *VehicleWheel) GetBrake() float64 {
+	return self.brake
+}
+
+// Sets the largest force the brake stops the wheel with to `brake`.
+func (self *VehicleWheel) SetBrake(brake float64) {
+	self.brake = brake
+	self.vehicle.chassis.WakeUp()
+}
+
+// Returns the point the suspension hangs from, in the local coordinates of the chassis.
+func (self *VehicleWheel) GetPosition() Vec3 {
+	return self.position
+}
+
+// Returns the radius of the wheel.
+func (self *VehicleWheel) GetRadius() float64 {
+	return self.radius
+}
+
+// Returns whether the wheel touched a shape in the last update.
+func (self *VehicleWheel) IsInContact() bool {
+	return self.inContact
+}
+
+// Returns the world position the wheel touched the ground at in the last update.
+func (self *VehicleWheel) GetContactPosition() Vec3 {
+	return self.contactPosition
+}
+
+// Returns the normal of the ground the wheel touched in the last update.
+func (self *VehicleWheel) GetContactNormal() Vec3 {
+	return self.contactNormal
+}
+
+// Returns the shape the wheel touched in the last update, or `nil` if the wheel is in the air.
+func (self *VehicleWheel) GetContactShape() *Shape {
+	return self.contactShape
+}
+
+// Returns the length of the suspension, from the suspension point to the center of the wheel.
+func (self *VehicleWheel) GetSuspensionLength() float64 {
+	return self.suspensionLength
+}
+
+// Returns the force the suspension pushed the chassis up with in the last update.
+func (self *VehicleWheel) GetSuspensionForce() float64 {
+	return self.suspensionForce
+}
+
+// Returns how much the tire slipped in the last update, from `0` while it grips to `1` as it loses all the grip it
+// needs.
+func (self *VehicleWheel) GetSlip() float64 {
+	return self.slip
+}
+
+// Returns the rolling angle of the wheel about its axle in radians.
+func (self *VehicleWheel) GetRotation() float64 {
+	return self.rotation
+}
+
+// Returns the world transform of the wheel for rendering. The wheel is placed at the end of the suspension, steered
+// and rolled about its axle, and otherwise has the axes of the chassis.
+func (self *VehicleWheel) GetTransform() Transform {
+	var tf Transform
+	self.GetTransformTo(&tf)
+	return tf
+}
+
+// Sets `transform` to the world transform of the wheel for rendering.
+func (self *VehicleWheel) GetTransformTo(transform *Transform) {
+	chassis := self.vehicle.chassis
+	up := self.vehicle.up
+	forward := self.vehicle.forward
+
+	center := self.position.AddScaled(up, -self.suspensionLength)
+	transform.position = chassis.GetWorldPoint(center)
+
+	// roll about the left axis of the chassis so that positive angles roll forward, then steer about the up axis
+	left := up.Cross(forward)
+	var steer, roll Quat
+	var steerRotation, rollRotation Mat3
+	steerAxis := up.Scale(MathUtil.Sin(self.steering * 0.5))
+	rollAxis := left.Scale(MathUtil.Sin(self.rotation * 0.5))
+	MathUtil.Quat_fromVec3AndFloat(&steer, &steerAxis, MathUtil.Cos(self.steering*0.5))
+	MathUtil.Quat_fromVec3AndFloat(&roll, &rollAxis, MathUtil.Cos(self.rotation*0.5))
+	MathUtil.Mat3_fromQuat(&steerRotation, &steer)
+	MathUtil.Mat3_fromQuat(&rollRotation, &roll)
+	MathUtil.Mat3_mul(&transform.rotation, &steerRotation, &rollRotation)
+	MathUtil.Mat3_mul(&transform.rotation, &chassis.transform.rotation, &transform.rotation)
+}
@@ -0,0 +1,45 @@
+package demos
+
+//////////////////////////////////////////////// VehicleWheelConfig
+// (goimo)
+// A vehicle wheel config is used for constructions of wheels of raycast vehicles.
+
+type VehicleWheelConfig struct {
+	// The point the suspension hangs from, in the local coordinates of the chassis.
+	Position Vec3
+
+	// The radius of the wheel.
+	Radius float64
+
+	// The length of the suspension at rest, from `Position` to the center of the wheel.
+	SuspensionRestLength float64
+
+	// The farthest the suspension compresses and extends from its rest length.
+	SuspensionMaxTravel float64
+
+	// The spring constant of the suspension per unit mass of the chassis, so that the same values work for light and
+	// heavy chassis.
+	SuspensionStiffness float64
+
+	// The damping coefficient of the suspension while compressing, per unit mass of the chassis.
+	SuspensionCompressionDamping float64
+
+	// The damping coefficient of the suspension while extending, per unit mass of the chassis.
+	SuspensionRelaxationDamping float64
+
+	// The coefficient of the tire friction. The tire grips until the impulse it needs exceeds this times the
+	// suspension impulse, and slips beyond that.
+	FrictionSlip float64
+}
+
+func NewVehicleWheelConfig() *VehicleWheelConfig {
+	return &VehicleWheelConfig{
+		Radius:                       0.4,
+		SuspensionRestLength:         0.3,
+		SuspensionMaxTravel:          0.2,
+		SuspensionStiffness:          40,
+		SuspensionCompressionDamping: 2,
+		SuspensionRelaxationDamping:  3,
+		FrictionSlip:                 1,
+	}
+}
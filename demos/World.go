@@ -1,5 +1,13 @@
 package demos
 
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
 ////////////////////// World (oimo/dynamics/World.go)
 // The physics simulation world. This manages entire the dynamic simulation. You can add rigid bodies and joints to the world to simulate them.
 
@@ -10,6 +18,9 @@ type World struct {
 	jointList     *Joint
 	jointListLast *Joint
 
+	articulationList     *Articulation
+	articulationListLast *Articulation
+
 	broadPhase     IBroadPhase
 	contactManager *ContactManager
 
@@ -18,17 +29,35 @@ type World struct {
 	numShapes      int
 	numIslands     int
 
+	numArticulations int
+
 	numVelocityIterations int
 	numPositionIterations int
+	numSubSteps           int
 
 	gravity Vec3
 
+	materialPairs map[materialPairKey]*MaterialPair
+
+	// fixed time step accumulator, see `Advance`
+	fixedTimeStep      float64
+	maxSteps           int
+	accumulatedTime    float64
+	interpolationAlpha float64
+
 	timeStep            *TimeStep
 	island              *Island
 	rigidBodyStack      []*RigidBody
 	solversInIslands    []IConstraintSolver
 	numSolversInIslands int
 
+	// parallel island solving, see `SetNumWorkers`
+	numWorkers  int
+	islands     []*Island
+	islandTasks []islandTask
+
+	deterministic bool
+
 	debugDraw *DebugDraw
 
 	rayCastWrapper    *RayCastWrapper
@@ -57,8 +86,15 @@ func NewWorld(broadPhaseType BroadPhaseType, gravity *Vec3) *World {
 	}
 	w.gravity = *gravity
 
+	w.materialPairs = make(map[materialPairKey]*MaterialPair)
+
 	w.numVelocityIterations = 10
 	w.numPositionIterations = 5
+	w.numSubSteps = 1
+	w.numWorkers = 1
+
+	w.fixedTimeStep = 1.0 / 60
+	w.maxSteps = 8
 
 	w.rayCastWrapper = NewRayCastWrapper()
 	w.convexCastWrapper = NewConvexCastWrapper()
@@ -101,10 +137,113 @@ func (w *World) solveIslands() {
 		w.rigidBodyStack = make([]*RigidBody, newStackSize)
 	}
 
+	// static rigid bodies are never added to islands, clear their contact impulses here
+	for b := w.rigidBodyList; b != nil; b = b.next {
+		if b._type == RigidBodyType_STATIC {
+			b.linearContactImpulse.Zero()
+			b.angularContactImpulse.Zero()
+		}
+	}
+
 	// build and solve islands
 	w.numIslands = 0
 	w.island.SetGravity(&w.gravity)
 	w.numSolversInIslands = 0
+	if w.numWorkers > 1 {
+		w.solveIslandsParallel()
+	} else {
+		w.solveIslandsSerial()
+	}
+
+	w.contactManager.postSolve()
+
+	// clear island flags
+	// clear forces and torques
+	// (OimoPhysics code loops two times for this)
+	for b := w.rigidBodyList; b != nil; {
+		next := b.next
+		b.addedToIsland = false
+		b.force.Zero()
+		b.torque.Zero()
+		b = next
+	}
+
+	for w.numSolversInIslands > 0 {
+		w.numSolversInIslands--
+		w.solversInIslands[w.numSolversInIslands].SetAddedToIsland(false)
+		w.solversInIslands[w.numSolversInIslands] = nil
+	}
+}
+
+// Builds all the islands first, as that walks the links shared between them, then solves them on `numWorkers`
+// goroutines. Whatever touches the world or the broad-phase afterwards runs in the order of the serial path, so that
+// the results are the same.
+func (w *World) solveIslandsParallel() {
+	numIslandsBuilt := 0
+	for b := w.rigidBodyList; b != nil; b = b.next {
+		if b.addedToIsland || b.sleeping || b._type == RigidBodyType_STATIC {
+			// never be the base of an island
+			continue
+		}
+		if b.isAlone() {
+			if b.articulationLink == nil {
+				w.islandTasks = append(w.islandTasks, islandTask{rigidBody: b})
+			}
+			continue
+		}
+
+		if numIslandsBuilt == len(w.islands) {
+			w.islands = append(w.islands, NewIsland())
+		}
+		island := w.islands[numIslandsBuilt]
+		numIslandsBuilt++
+		island.SetGravity(&w.gravity)
+		w.buildIsland(island, b)
+		w.islandTasks = append(w.islandTasks, islandTask{island: island})
+	}
+	w.numIslands = len(w.islandTasks)
+
+	// solve
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(w.numWorkers, len(w.islandTasks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(w.islandTasks) {
+					return
+				}
+				t := &w.islandTasks[i]
+				if t.island != nil {
+					t.awake = t.island.solve(*w.timeStep, w.numVelocityIterations, w.numPositionIterations, w.numSubSteps)
+				} else {
+					w.island.stepSingleRigidBody(*w.timeStep, t.rigidBody)
+					t.awake = !t.rigidBody.sleeping
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// post-solve and synchronize shapes in order
+	for i := range w.islandTasks {
+		t := &w.islandTasks[i]
+		if t.island != nil {
+			if t.awake {
+				t.island.finish()
+			}
+			t.island.clear()
+		} else if t.awake {
+			t.rigidBody.syncShapes()
+		}
+		*t = islandTask{}
+	}
+	w.islandTasks = w.islandTasks[:0]
+}
+
+func (w *World) solveIslandsSerial() {
 	for b := w.rigidBodyList; b != nil; {
 		next := b.next
 
@@ -114,45 +253,33 @@ func (w *World) solveIslands() {
 			continue
 		}
 		if b.isAlone() {
+			if b.articulationLink != nil {
+				// stepped by the articulation
+				b = next
+				continue
+			}
 			w.island.StepSingleRigidBody(*w.timeStep, b)
 			w.numIslands++
 			b = next
 			continue
 		}
 
-		w.buildIsland(b)
+		w.buildIsland(w.island, b)
 
-		w.island.Step(*w.timeStep, w.numVelocityIterations, w.numPositionIterations)
+		w.island.Step(*w.timeStep, w.numVelocityIterations, w.numPositionIterations, w.numSubSteps)
 		w.island.clear()
 		w.numIslands++
 
 		b = next
 	}
-
-	w.contactManager.postSolve()
-
-	// clear island flags
-	// clear forces and torques
-	// (OimoPhysics code loops two times for this)
-	for b := w.rigidBodyList; b != nil; {
-		next := b.next
-		b.addedToIsland = false
-		b.force.Zero()
-		b.torque.Zero()
-		b = next
-	}
-
-	for w.numSolversInIslands > 0 {
-		w.numSolversInIslands--
-		w.solversInIslands[w.numSolversInIslands].SetAddedToIsland(false)
-		w.solversInIslands[w.numSolversInIslands] = nil
-	}
 }
 
-func (w *World) buildIsland(base *RigidBody) {
+// Builds the island connected to `base` into `island`. Static rigid bodies are not added to the island, as they may
+// be shared by many islands.
+func (w *World) buildIsland(island *Island, base *RigidBody) {
 	// begin DFS
 	stackCount := 1
-	w.island.AddRigidBody(base)
+	island.AddRigidBody(base)
 	w.rigidBodyStack[0] = base
 
 	for stackCount > 0 {
@@ -161,11 +288,6 @@ func (w *World) buildIsland(base *RigidBody) {
 		rb := w.rigidBodyStack[stackCount]
 		w.rigidBodyStack[stackCount] = nil
 
-		// stop searching deeper
-		if rb._type == RigidBodyType_STATIC {
-			continue
-		}
-
 		// searching contacts
 		for cl := rb.contactLinkList; cl != nil; {
 			next := cl.next
@@ -173,7 +295,7 @@ func (w *World) buildIsland(base *RigidBody) {
 			// ignore if not touching
 			cc := cl.contact.contactConstraint
 			ccs := cl.contact.contactConstraint.solver
-			if cc.IsTouching() && !ccs.GetAddedToIsland() {
+			if cc.IsTouching() && cc.enabled && !ccs.GetAddedToIsland() {
 
 				// add to constraint array (to clear island flag later)
 				if len(w.solversInIslands) == w.numSolversInIslands {
@@ -183,12 +305,12 @@ func (w *World) buildIsland(base *RigidBody) {
 				w.numSolversInIslands++
 
 				// add to island
-				w.island.AddConstraintSolver(ccs, cc.positionCorrectionAlgorithm)
+				island.AddConstraintSolver(ccs, cc.positionCorrectionAlgorithm)
 
 				// push the other rigid body if not added
 				other := cl.other
-				if !other.addedToIsland {
-					w.island.AddRigidBody(other)
+				if !other.addedToIsland && other._type != RigidBodyType_STATIC {
+					island.AddRigidBody(other)
 					w.rigidBodyStack[stackCount] = other
 					stackCount++
 				}
@@ -213,12 +335,12 @@ func (w *World) buildIsland(base *RigidBody) {
 				w.numSolversInIslands++
 
 				// add to island
-				w.island.AddConstraintSolver(js, j.positionCorrectionAlgorithm)
+				island.AddConstraintSolver(js, j.positionCorrectionAlgorithm)
 
 				// push the other rigid body if not added
 				other := jl.other
-				if !other.addedToIsland {
-					w.island.AddRigidBody(other)
+				if !other.addedToIsland && other._type != RigidBodyType_STATIC {
+					island.AddRigidBody(other)
 					w.rigidBodyStack[stackCount] = other
 					stackCount++
 				}
@@ -399,7 +521,70 @@ func (w *World) Step(timeStep float64) {
 
 	// Profile hook: totalTime
 	w.updateContacts()
+	for a := w.articulationList; a != nil; a = a.next {
+		a.preSolve(w.timeStep, w.gravity, w.numVelocityIterations)
+	}
 	w.solveIslands()
+	for a := w.articulationList; a != nil; a = a.next {
+		a.postSolve(w.timeStep)
+	}
+}
+
+// Advances the simulation by the real elapsed time `realDelta`, in fixed steps of `GetFixedTimeStep()`. The time
+// left over is accumulated for the next call, and the fraction of a step it makes is returned by
+// `GetInterpolationAlpha()`, to render rigid bodies with `RigidBody.GetInterpolatedTransform`. At most
+// `GetMaxSteps()` steps are taken, and the time beyond them is dropped so that a slow frame doesn't make the
+// following frames slower. Returns the number of steps taken.
+func (self *World) Advance(realDelta float64) int {
+	if realDelta > 0 {
+		self.accumulatedTime += realDelta
+	}
+
+	numSteps := 0
+	for self.accumulatedTime >= self.fixedTimeStep {
+		if numSteps == self.maxSteps {
+			// drop the rest to catch up
+			self.accumulatedTime -= MathUtil.Floor(self.accumulatedTime/self.fixedTimeStep) * self.fixedTimeStep
+			break
+		}
+		self.Step(self.fixedTimeStep)
+		self.accumulatedTime -= self.fixedTimeStep
+		numSteps++
+	}
+
+	self.interpolationAlpha = self.accumulatedTime / self.fixedTimeStep
+	return numSteps
+}
+
+// Returns the fraction of a fixed step accumulated by `Advance` and not simulated yet, in [0, 1).
+func (self *World) GetInterpolationAlpha() float64 {
+	return self.interpolationAlpha
+}
+
+// Returns the time step `Advance` simulates the world by.
+func (self *World) GetFixedTimeStep() float64 {
+	return self.fixedTimeStep
+}
+
+// Sets the time step `Advance` simulates the world by to `fixedTimeStep`. The default is `1 / 60`.
+func (self *World) SetFixedTimeStep(fixedTimeStep float64) {
+	if fixedTimeStep <= 0 {
+		panic("The fixed time step must be positive.")
+	}
+	self.fixedTimeStep = fixedTimeStep
+}
+
+// Returns the maximum number of steps `Advance` takes in a call.
+func (self *World) GetMaxSteps() int {
+	return self.maxSteps
+}
+
+// Sets the maximum number of steps `Advance` takes in a call to `maxSteps`. The default is `8`.
+func (self *World) SetMaxSteps(maxSteps int) {
+	if maxSteps < 1 {
+		panic("The maximum number of steps must be at least one.")
+	}
+	self.maxSteps = maxSteps
 }
 
 func (self *World) AddRigidBody(rigidBody *RigidBody) {
@@ -449,12 +634,12 @@ func (self *World) AddJoint(joint *Joint) {
 	self.jointList, self.jointListLast = DoubleList_push(self.jointList, self.jointListLast, joint)
 	joint.world = self
 	joint.attachLinks()
-	joint.syncAnchors()
+	joint.impl.syncAnchors()
 
 	self.numJoints++
 }
 
-// Removes the joint `joint` from the simulation world.
+// Removes the joint `joint` from the simulation world. Gear joints coupling `joint` are removed along with it.
 func (self *World) RemoveJoint(joint *Joint) {
 	if joint.world != self {
 		panic("The joint doesn't belong to the world.")
@@ -464,6 +649,247 @@ func (self *World) RemoveJoint(joint *Joint) {
 	joint.detachLinks()
 
 	self.numJoints--
+
+	// a gear joint can't work without the joints it couples
+	for j := self.jointList; j != nil; {
+		next := j.next
+		if gear, ok := j.impl.(*GearJoint); ok && (gear.joint1 == joint || gear.joint2 == joint) {
+			self.RemoveJoint(j)
+		}
+		j = next
+	}
+}
+
+// Adds the articulation `articulation` to the simulation world. The rigid bodies of the links must be added to the
+// world separately.
+func (self *World) AddArticulation(articulation *Articulation) {
+	if articulation.world != nil {
+		panic("An articulation cannot belong to multiple worlds.")
+	}
+
+	self.articulationList, self.articulationListLast = DoubleList_push(self.articulationList, self.articulationListLast, articulation)
+	articulation.world = self
+	for i := range articulation.numLinks {
+		articulation.attachLink(articulation.links[i])
+	}
+
+	self.numArticulations++
+}
+
+// Removes the articulation `articulation` from the simulation world. The rigid bodies of the links stay in the world
+// as free rigid bodies.
+func (self *World) RemoveArticulation(articulation *Articulation) {
+	if articulation.world != self {
+		panic("The articulation doesn't belong to the world.")
+	}
+	self.articulationList, self.articulationListLast = DoubleList_remove(self.articulationList, self.articulationListLast, articulation)
+	articulation.world = nil
+	for i := range articulation.numLinks {
+		articulation.detachLink(articulation.links[i])
+	}
+
+	self.numArticulations--
+}
+
+// Saves the simulation state of the world: the transforms, velocities and sleep states of the rigid bodies, the
+// contacts with their warm starting impulses, the joints with their impulses, the joint coordinates of the
+// articulations and the broad-phase proxies. Restore it with `Restore`.
+func (self *World) Snapshot() *WorldSnapshot {
+	snapshot := &WorldSnapshot{
+		world:              self,
+		timeStep:           *self.timeStep,
+		accumulatedTime:    self.accumulatedTime,
+		interpolationAlpha: self.interpolationAlpha,
+	}
+
+	for b := self.rigidBodyList; b != nil; b = b.next {
+		snapshot.rigidBodies = append(snapshot.rigidBodies, rigidBodySnapshot{
+			rigidBody:             b,
+			transform:             b.transform,
+			pTransform:            b.pTransform,
+			vel:                   b.vel,
+			angVel:                b.angVel,
+			force:                 b.force,
+			torque:                b.torque,
+			linearContactImpulse:  b.linearContactImpulse,
+			angularContactImpulse: b.angularContactImpulse,
+			sleeping:              b.sleeping,
+			sleepTime:             b.sleepTime,
+		})
+		for s := b.shapeList; s != nil; s = s.next {
+			snapshot.shapes = append(snapshot.shapes, shapeSnapshot{
+				transform:    s.transform,
+				pTransform:   s.pTransform,
+				aabb:         s.aabb,
+				displacement: s.displacement,
+				proxyAabb:    Aabb{Min: *s.proxy.GetAabbMin(), Max: *s.proxy.GetAabbMax()},
+			})
+		}
+	}
+
+	for j := self.jointList; j != nil; j = j.next {
+		js := jointSnapshot{
+			joint:         j,
+			impulses:      append([]JointImpulse(nil), j.impulses...),
+			appliedForce:  j.appliedForce,
+			appliedTorque: j.appliedTorque,
+		}
+		if ds, ok := j.solver.(*DirectJointConstraintSolver); ok {
+			js.velBoundaryIndices = append([]int(nil), ds.velBoundarySelector.indices...)
+			js.posBoundaryIndices = append([]int(nil), ds.posBoundarySelector.indices...)
+		}
+		switch impl := j.impl.(type) {
+		case *DistanceJoint:
+			js.directions[0] = impl.direction
+		case *PulleyJoint:
+			js.directions = [2]Vec3{impl.direction1, impl.direction2}
+		case *GearJoint:
+			js.coordinates = [2]float64{impl.coordinate1, impl.coordinate2}
+			js.rawCoordinates = [2]float64{impl.rawCoordinate1, impl.rawCoordinate2}
+			js.constant = impl.constant
+		}
+		snapshot.joints = append(snapshot.joints, js)
+	}
+
+	for a := self.articulationList; a != nil; a = a.next {
+		for i := range a.numLinks {
+			l := a.links[i]
+			snapshot.articulationLinks = append(snapshot.articulationLinks, articulationLinkSnapshot{
+				jointPosition: l.jointPosition,
+				jointRotation: l.jointRotation,
+				jointVelocity: l.jointVelocity,
+			})
+		}
+	}
+
+	self.contactManager.save(snapshot)
+	if bvh, ok := self.broadPhase.(*BvhBroadPhase); ok {
+		snapshot.bvh = bvh.save()
+	}
+	return snapshot
+}
+
+// Restores the simulation state saved by `Snapshot`, so that stepping the world afterwards gives the same results as
+// it did after the snapshot was taken. Joints added or removed since are removed or added back, but the rigid bodies,
+// their shapes and the articulations must be the same. Contacts are recreated without contact events, so pointers to
+// the old contacts must not be kept.
+func (self *World) Restore(snapshot *WorldSnapshot) {
+	if snapshot.world != self {
+		panic("The snapshot doesn't belong to the world.")
+	}
+
+	*self.timeStep = snapshot.timeStep
+	self.accumulatedTime = snapshot.accumulatedTime
+	self.interpolationAlpha = snapshot.interpolationAlpha
+
+	// rigid bodies
+	i, si := 0, 0
+	for b := self.rigidBodyList; b != nil; b = b.next {
+		if i == len(snapshot.rigidBodies) || snapshot.rigidBodies[i].rigidBody != b {
+			panic("The rigid bodies have changed since the snapshot.")
+		}
+		bs := &snapshot.rigidBodies[i]
+		i++
+		b.transform = bs.transform
+		b.pTransform = bs.pTransform
+		b.vel = bs.vel
+		b.angVel = bs.angVel
+		b.force = bs.force
+		b.torque = bs.torque
+		b.linearContactImpulse = bs.linearContactImpulse
+		b.angularContactImpulse = bs.angularContactImpulse
+		b.sleeping = bs.sleeping
+		b.sleepTime = bs.sleepTime
+		b.pseudoVel.Zero()
+		b.angPseudoVel.Zero()
+		b.updateInvInertia()
+
+		for s := b.shapeList; s != nil; s = s.next {
+			ss := &snapshot.shapes[si]
+			si++
+			s.transform = ss.transform
+			s.pTransform = ss.pTransform
+			s.aabb = ss.aabb
+			s.displacement = ss.displacement
+			s.proxy.SetAabb(&ss.proxyAabb)
+		}
+	}
+	if i != len(snapshot.rigidBodies) {
+		panic("The rigid bodies have changed since the snapshot.")
+	}
+
+	// articulations
+	i = 0
+	for a := self.articulationList; a != nil; a = a.next {
+		for k := range a.numLinks {
+			if i == len(snapshot.articulationLinks) {
+				panic("The articulations have changed since the snapshot.")
+			}
+			l, ls := a.links[k], &snapshot.articulationLinks[i]
+			i++
+			l.jointPosition = ls.jointPosition
+			l.jointRotation = ls.jointRotation
+			l.jointVelocity = ls.jointVelocity
+		}
+	}
+	if i != len(snapshot.articulationLinks) {
+		panic("The articulations have changed since the snapshot.")
+	}
+
+	// joints, relinked in the saved order to restore the order of the joint links of the rigid bodies
+	for self.jointList != nil {
+		j := self.jointList
+		self.jointList, self.jointListLast = DoubleList_remove(self.jointList, self.jointListLast, j)
+		j.world = nil
+		j.detachLinks()
+	}
+	self.numJoints = 0
+	for k := range snapshot.joints {
+		js := &snapshot.joints[k]
+		j := js.joint
+		if j.world != nil {
+			panic("A joint of the snapshot has been added to another world.")
+		}
+		self.jointList, self.jointListLast = DoubleList_push(self.jointList, self.jointListLast, j)
+		j.world = self
+		j.attachLinks()
+		j.impl.syncAnchors()
+		copy(j.impulses, js.impulses)
+		j.appliedForce = js.appliedForce
+		j.appliedTorque = js.appliedTorque
+		if ds, ok := j.solver.(*DirectJointConstraintSolver); ok {
+			copy(ds.velBoundarySelector.indices, js.velBoundaryIndices)
+			copy(ds.posBoundarySelector.indices, js.posBoundaryIndices)
+		}
+		self.numJoints++
+	}
+
+	// after all the anchors are synchronized, as gear joints read the coordinates of the joints they couple
+	for k := range snapshot.joints {
+		js := &snapshot.joints[k]
+		switch impl := js.joint.impl.(type) {
+		case *DistanceJoint:
+			impl.direction = js.directions[0]
+		case *PulleyJoint:
+			impl.direction1, impl.direction2 = js.directions[0], js.directions[1]
+		case *GearJoint:
+			impl.coordinate1, impl.coordinate2 = js.coordinates[0], js.coordinates[1]
+			impl.rawCoordinate1, impl.rawCoordinate2 = js.rawCoordinates[0], js.rawCoordinates[1]
+			impl.constant = js.constant
+		}
+	}
+
+	// attaching links woke the rigid bodies up
+	for k := range snapshot.rigidBodies {
+		bs := &snapshot.rigidBodies[k]
+		bs.rigidBody.sleeping = bs.sleeping
+		bs.rigidBody.sleepTime = bs.sleepTime
+	}
+
+	self.contactManager.restore(snapshot)
+	if bvh, ok := self.broadPhase.(*BvhBroadPhase); ok {
+		bvh.restore(snapshot.bvh)
+	}
 }
 
 // Sets the debug draw interface to `debugDraw`. Call `World.debugDraw` to draw the simulation world.
@@ -519,6 +945,11 @@ func (self *World) GetJointList() *Joint {
 	return self.jointList
 }
 
+// Returns the list of the articulations added to the world.
+func (self *World) GetArticulationList() *Articulation {
+	return self.articulationList
+}
+
 // Returns the broad-phase collision detection algorithm.
 func (self *World) GetBroadPhase() IBroadPhase {
 	return self.broadPhase
@@ -539,6 +970,11 @@ func (self *World) GetNumJoints() int {
 	return self.numJoints
 }
 
+// Returns the number of the articulations added to the world.
+func (self *World) GetNumArticulations() int {
+	return self.numArticulations
+}
+
 // Returns the number of the shapes added to the world.
 func (self *World) GetNumShapes() int {
 	return self.numShapes
@@ -569,6 +1005,81 @@ func (self *World) SetNumPositionIterations(numPositionIterations int) {
 	self.numPositionIterations = numPositionIterations
 }
 
+// Returns the number of sub-steps each step is split into.
+func (self *World) GetNumSubSteps() int {
+	return self.numSubSteps
+}
+
+// Sets the number of sub-steps each step is split into to `numSubSteps`. If this is greater than `1`, contacts are
+// detected once per step, and the velocity solver and the integration run in every sub-step, relaxing the contacts by
+// the positions reached so far. The velocity iterations are shared among the sub-steps, so set a low number of velocity
+// iterations, even `1` per sub-step, for stiff stacks and high mass ratios at about the same cost. Contact impulses
+// are reported for the last sub-step. Islands with articulations are not sub-stepped. The default is `1`.
+func (self *World) SetNumSubSteps(numSubSteps int) {
+	self.numSubSteps = numSubSteps
+}
+
+// Returns the number of goroutines the narrow-phase and the islands are run on.
+func (self *World) GetNumWorkers() int {
+	return self.numWorkers
+}
+
+// Sets the number of goroutines islands are solved on to `numWorkers`. If this is greater than `1`, the narrow-phase
+// collision detection is split among them, and all the islands are built first and then solved concurrently, with the
+// same results as running them one after another. Contact, trigger and joint break callbacks are still called on the
+// calling goroutine, in the same order. The default is `1`.
+func (self *World) SetNumWorkers(numWorkers int) {
+	self.numWorkers = numWorkers
+	self.contactManager.setNumWorkers(numWorkers)
+}
+
+// Returns whether the simulation is deterministic, see `SetDeterministic`.
+func (self *World) IsDeterministic() bool {
+	return self.deterministic
+}
+
+// Sets whether the simulation is deterministic to `deterministic`. In the deterministic mode, new contacts are created
+// in the order of the ids of their shapes instead of the order the broad-phase finds them in. Along with the rest of
+// the simulation, which has no map iteration, seeds its random numbers and gives the same results with any number of
+// workers, this makes a world built and stepped the same way give bit-identical results every run of the same build.
+// Lockstep across architectures is not supported: the compiler fuses multiplies and adds into FMA instructions, which
+// round differently, on arm64, ppc64le, s390x, riscv64, loong64 and on amd64 with GOAMD64=v3 or later, so an amd64
+// server and an arm64 client diverge. Only machines running builds of the same Go version, GOARCH and GOAMD64 give
+// the same results. Use `Checksum` to compare worlds. The default is `false`.
+func (self *World) SetDeterministic(deterministic bool) {
+	self.deterministic = deterministic
+	self.contactManager.deterministic = deterministic
+}
+
+// Returns a checksum of the types, transforms, velocities and sleep states of all the rigid bodies, in the order they
+// were added. Worlds in the same state have the same checksum.
+func (self *World) Checksum() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	write := func(v float64) {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	}
+	for b := self.rigidBodyList; b != nil; b = b.next {
+		p, r := &b.transform.position, &b.transform.rotation
+		write(float64(b._type))
+		for _, v := range [...]float64{
+			p.x, p.y, p.z,
+			r.e00, r.e01, r.e02, r.e10, r.e11, r.e12, r.e20, r.e21, r.e22,
+			b.vel.x, b.vel.y, b.vel.z,
+			b.angVel.x, b.angVel.y, b.angVel.z,
+		} {
+			write(v)
+		}
+		if b.sleeping {
+			write(1)
+		} else {
+			write(0)
+		}
+	}
+	return h.Sum64()
+}
+
 // Returns the gravitational acceleration of the simulation world.
 func (self *World) GetGravity() Vec3 {
 	return self.gravity
@@ -579,6 +1090,31 @@ func (self *World) SetGravity(gravity Vec3) {
 	self.gravity = gravity
 }
 
+// Sets the coefficients used for the contacts between shapes of the materials `material1` and `material2` to `pair`,
+// regardless of the combine modes of the materials. The order of the materials does not matter. Set `nil` to remove
+// the pair.
+func (self *World) SetMaterialPair(material1, material2 *Material, pair *MaterialPair) {
+	if pair == nil {
+		delete(self.materialPairs, materialPairKey{material1, material2})
+		delete(self.materialPairs, materialPairKey{material2, material1})
+		return
+	}
+	self.materialPairs[materialPairKey{material1, material2}] = pair
+	self.materialPairs[materialPairKey{material2, material1}] = pair
+}
+
+// Returns the material pair set for the materials `material1` and `material2`, or `nil` if there is no such pair.
+func (self *World) GetMaterialPair(material1, material2 *Material) *MaterialPair {
+	return self.materialPairs[materialPairKey{material1, material2}]
+}
+
+// an island or a single rigid body to be solved by a worker
+type islandTask struct {
+	island    *Island
+	rigidBody *RigidBody
+	awake     bool
+}
+
 // ray cast wrapper (broadphase -> world)
 type RayCastWrapper struct { // implements IBroadPhaseProxyCallback
 	callback IRayCastCallback
@@ -595,7 +1131,7 @@ func NewRayCastWrapper() *RayCastWrapper {
 }
 
 func (self *RayCastWrapper) Process(proxy IProxy) { // override
-	shape := proxy.(*Proxy).userData.(*Shape)
+	shape := proxy.GetUserData().(*Shape)
 
 	if shape.geom.RayCast(self.begin, self.end, &shape.transform, self.rayCastHit) {
 		self.callback.Process(shape, self.rayCastHit)
@@ -611,18 +1147,20 @@ type ConvexCastWrapper struct { // implements IBroadPhaseProxyCallback
 
 	rayCastHit *RayCastHit
 	zero       Vec3
+	gjkEpa     *GjkEpa
 }
 
 func NewConvexCastWrapper() *ConvexCastWrapper {
 	c := &ConvexCastWrapper{
 		rayCastHit: NewRayCastHit(),
+		gjkEpa:     NewGjkEpa(),
 	}
 	c.begin.Identity()
 	return c
 }
 
 func (self *ConvexCastWrapper) Process(proxy IProxy) { // override
-	shape := proxy.(*Proxy).userData.(*Shape)
+	shape := proxy.GetUserData().(*Shape)
 	t := shape.geom.GetType()
 
 	if t < GeometryType_CONVEX_MIN || t > GeometryType_CONVEX_MAX {
@@ -630,7 +1168,7 @@ func (self *ConvexCastWrapper) Process(proxy IProxy) { // override
 	}
 
 	geom := shape.geom
-	if GjkEpaInstance.ConvexCast(self.convex, geom.(IConvexGeometry), &self.begin, &shape.transform, self.translation, self.zero, self.rayCastHit) {
+	if self.gjkEpa.ConvexCast(self.convex, geom.(IConvexGeometry), &self.begin, &shape.transform, self.translation, self.zero, self.rayCastHit) {
 		self.callback.Process(shape, self.rayCastHit)
 	}
 }
@@ -648,7 +1186,7 @@ func NewAabbTestWrapper() *AabbTestWrapper {
 }
 
 func (self *AabbTestWrapper) Process(proxy IProxy) { // override
-	shape := proxy.(*Proxy).userData.(*Shape)
+	shape := proxy.GetUserData().(*Shape)
 	shapeAabb := shape.aabb
 
 	// check if aabbs overlap again as proxies can be fattened by broadphase
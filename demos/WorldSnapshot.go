@@ -0,0 +1,103 @@
+package demos
+
+////////////////////// WorldSnapshot
+// The simulation state of a world saved by `World.Snapshot`, to be restored by `World.Restore`. This is opaque.
+
+type WorldSnapshot struct {
+	world *World
+
+	timeStep           TimeStep
+	accumulatedTime    float64
+	interpolationAlpha float64
+
+	rigidBodies       []rigidBodySnapshot
+	shapes            []shapeSnapshot
+	joints            []jointSnapshot
+	articulationLinks []articulationLinkSnapshot
+	contacts          []contactSnapshot
+	bvh               *bvhSnapshot
+}
+
+type rigidBodySnapshot struct {
+	rigidBody *RigidBody
+
+	transform             Transform
+	pTransform            Transform
+	vel                   Vec3
+	angVel                Vec3
+	force                 Vec3
+	torque                Vec3
+	linearContactImpulse  Vec3
+	angularContactImpulse Vec3
+	sleeping              bool
+	sleepTime             float64
+}
+
+type shapeSnapshot struct {
+	transform    Transform
+	pTransform   Transform
+	aabb         Aabb
+	displacement Vec3
+
+	// the fat AABB of the proxy
+	proxyAabb Aabb
+}
+
+type jointSnapshot struct {
+	joint *Joint
+
+	impulses      []JointImpulse
+	appliedForce  Vec3
+	appliedTorque Vec3
+
+	// the order the direct solver tries the boundaries in
+	velBoundaryIndices []int
+	posBoundaryIndices []int
+
+	// the state kept across steps by distance, pulley and gear joints
+	directions     [2]Vec3
+	coordinates    [2]float64
+	rawCoordinates [2]float64
+	constant       float64
+}
+
+type articulationLinkSnapshot struct {
+	jointPosition float64
+	jointRotation Mat3
+	jointVelocity Vec3
+}
+
+type contactSnapshot struct {
+	s1 *Shape
+	s2 *Shape
+
+	latest          bool
+	shouldBeSkipped bool
+	touching        bool
+	ptouching       bool
+	passingOneWay   bool
+
+	gjkCache   *GjkCache
+	manifold   Manifold
+	points     []ManifoldPoint
+	constraint ContactConstraint
+}
+
+type bvhSnapshot struct {
+	// the nodes of the tree in pre-order
+	nodes []bvhNodeSnapshot
+
+	// the proxies in the order of the leaf list
+	leaves []*BvhProxy
+
+	movedProxies []*BvhProxy
+}
+
+type bvhNodeSnapshot struct {
+	// nil for internal nodes
+	proxy *BvhProxy
+
+	height  int
+	aabbMin Vec3
+	aabbMax Vec3
+}
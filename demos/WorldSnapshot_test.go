@@ -0,0 +1,33 @@
+package demos
+
+import (
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	w := parallelTestWorld(4, nil)
+	for range 30 {
+		w.Step(1.0 / 60)
+	}
+
+	snapshot := w.Snapshot()
+	saved := w.Checksum()
+	checksums := make([]uint64, 60)
+	for i := range checksums {
+		w.Step(1.0 / 60)
+		checksums[i] = w.Checksum()
+	}
+
+	// removed joints are added back
+	w.RemoveJoint(w.GetJointList())
+	w.Restore(snapshot)
+	if w.Checksum() != saved {
+		t.Fatal("restoring changed the checksum")
+	}
+	for i := range checksums {
+		w.Step(1.0 / 60)
+		if w.Checksum() != checksums[i] {
+			t.Fatalf("step %d: checksum differs after restoring", i)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package demos
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+// steps `w` by `numSteps` steps of 1/60 seconds
+func stepWorld(w *World, numSteps int) {
+	for range numSteps {
+		w.Step(1.0 / 60)
+	}
+}
+
+// creates a world with a static ground box under gravity, whose top is at y = 0.5
+func groundTestWorld() *World {
+	w := NewWorld(BroadPhaseType_BVH, nil)
+	rc := NewRigidBodyConfig()
+	rc.Type = RigidBodyType_STATIC
+	ground := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{50, 0.5, 50})
+	ground.AddShape(NewShape(sc))
+	w.AddRigidBody(ground)
+	return w
+}
+
+func TestAdvanceFixedTimeStep(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, &Vec3{})
+	w.SetFixedTimeStep(0.25)
+	rc := NewRigidBodyConfig()
+	rb := NewRigidBody(rc)
+	sc := NewShapeConfig()
+	sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+	rb.AddShape(NewShape(sc))
+	w.AddRigidBody(rb)
+	rb.SetLinearVelocity(Vec3{1, 0, 0})
+
+	if n := w.Advance(0.6); n != 2 || math.Abs(w.GetInterpolationAlpha()-0.4) > 1e-9 {
+		t.Fatalf("took %v steps, alpha %v", n, w.GetInterpolationAlpha())
+	}
+
+	// a slow frame is capped at the maximum steps, and the time beyond them is dropped except for the fraction
+	if n := w.Advance(10); n != w.GetMaxSteps() || math.Abs(w.GetInterpolationAlpha()-0.4) > 1e-9 {
+		t.Fatalf("took %v steps, alpha %v", n, w.GetInterpolationAlpha())
+	}
+	if x := rb.GetPosition().x; math.Abs(x-2.5) > 1e-9 {
+		t.Fatalf("the body moved to %v", x)
+	}
+	if tf := rb.GetInterpolatedTransform(w.GetInterpolationAlpha()); math.Abs(tf.position.x-2.35) > 1e-9 {
+		t.Fatalf("the body is rendered at %v", tf.position.x)
+	}
+	if n := w.Advance(0.05); n != 0 || math.Abs(w.GetInterpolationAlpha()-0.6) > 1e-9 {
+		t.Fatalf("took %v steps, alpha %v", n, w.GetInterpolationAlpha())
+	}
+}
+
+func TestSetMaxStepsRejectsZero(t *testing.T) {
+	w := NewWorld(BroadPhaseType_BVH, nil)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("set the maximum steps to 0")
+		}
+	}()
+	w.SetMaxSteps(0)
+}
+
+// builds a tower of unit boxes on the ground, the top box is `topDensity` times heavier than the others
+func benchStackWorld(numBoxes int, topDensity float64) (*World, *RigidBody) {
+	w := groundTestWorld()
+	var top *RigidBody
+	for i := range numBoxes {
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{0, 1 + float64(i), 0}
+		top = NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		if i == numBoxes-1 {
+			sc.Density = topDensity
+		}
+		top.AddShape(NewShape(sc))
+		w.AddRigidBody(top)
+	}
+	return w, top
+}
+
+// Simulates 5 seconds of a tower of 10 boxes with the same total number of velocity iterations, and reports how far
+// the top box has drifted from its initial position.
+func BenchmarkStacking(b *testing.B) {
+	for _, topDensity := range []float64{1, 10} {
+		for _, numSubSteps := range []int{1, 4} {
+			b.Run(fmt.Sprintf("mass_ratio=%v/sub_steps=%d", topDensity, numSubSteps), func(b *testing.B) {
+				drift := 0.0
+				for range b.N {
+					w, top := benchStackWorld(10, topDensity)
+					w.SetNumSubSteps(numSubSteps)
+					w.SetNumVelocityIterations(10)
+					start := top.GetPosition()
+					for range 300 {
+						w.Step(1.0 / 60)
+					}
+					d := top.GetPosition()
+					d.SubEq(start)
+					drift += d.Length()
+				}
+				b.ReportMetric(drift/float64(b.N), "drift/op")
+			})
+		}
+	}
+}
+
+func TestSubStepsOnSlope(t *testing.T) {
+	// returns how far a box resting on a slope of friction 1 slides down in 3 seconds
+	slide := func(numSubSteps int) float64 {
+		w := NewWorld(BroadPhaseType_BVH, nil)
+		w.SetNumSubSteps(numSubSteps)
+		rc := NewRigidBodyConfig()
+		rc.Type = RigidBodyType_STATIC
+		MathUtil.Mat3_fromEulerXyz(&rc.Rotation, &Vec3{0, 0, 0.6})
+		slope := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{10, 0.5, 10})
+		sc.Friction = 1
+		slope.AddShape(NewShape(sc))
+		w.AddRigidBody(slope)
+
+		rc.Type = RigidBodyType_DYNAMIC
+		rc.Position = slope.GetWorldPoint(Vec3{0, 1, 0})
+		rc.AutoSleep = false
+		box := NewRigidBody(rc)
+		sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+		box.AddShape(NewShape(sc))
+		w.AddRigidBody(box)
+
+		start := box.GetPosition()
+		stepWorld(w, 180)
+		d := box.GetPosition()
+		d = slope.GetLocalVector(d.Sub(start))
+		return MathUtil.Sqrt(d.x*d.x + d.z*d.z)
+	}
+
+	// the friction impulses carry over between the sub-steps, so the box stays as still as without sub-steps
+	withoutSubSteps := slide(1)
+	withSubSteps := slide(4)
+	if withoutSubSteps > 0.01 || withSubSteps > withoutSubSteps {
+		t.Fatalf("the box slid by %v with sub-steps and by %v without", withSubSteps, withoutSubSteps)
+	}
+}
+
+// records the contact events as the ids of the shapes
+type eventRecorder struct {
+	*ContactCallback
+	events [][3]int
+}
+
+func (r *eventRecorder) BeginContact(c *Contact) {
+	r.events = append(r.events, [3]int{0, c.s1.id, c.s2.id})
+}
+
+func (r *eventRecorder) PreSolve(c *Contact) {
+	r.events = append(r.events, [3]int{1, c.s1.id, c.s2.id})
+}
+
+func (r *eventRecorder) EndContact(c *Contact) {
+	r.events = append(r.events, [3]int{2, c.s1.id, c.s2.id})
+}
+
+// builds `numTowers` separate towers of boxes on a shared static ground, the top box of each tower hangs on a pendulum
+func parallelTestWorld(numTowers int, callback IContactCallback) *World {
+	w := groundTestWorld()
+	for t := range numTowers {
+		x := float64(t)*3 - 20
+		var prev *RigidBody
+		for i := range 4 {
+			rc := NewRigidBodyConfig()
+			rc.Position = Vec3{x, 1 + float64(i)*1.1, 0.1 * float64(i)}
+			b := NewRigidBody(rc)
+			sc := NewShapeConfig()
+			sc.Geometry = NewBoxGeometry(Vec3{0.5, 0.5, 0.5})
+			sc.ContactCallback = callback
+			b.AddShape(NewShape(sc))
+			w.AddRigidBody(b)
+			prev = b
+		}
+		rc := NewRigidBodyConfig()
+		rc.Position = Vec3{x + 1, 6, 0}
+		bob := NewRigidBody(rc)
+		sc := NewShapeConfig()
+		sc.Geometry = NewBoxGeometry(Vec3{0.2, 0.2, 0.2})
+		bob.AddShape(NewShape(sc))
+		w.AddRigidBody(bob)
+		jc := NewRevoluteJointConfig().Init(prev, bob, Vec3{x, 5, 0}, Vec3{0, 0, 1})
+		w.AddJoint(NewRevoluteJoint(jc).Joint)
+	}
+	return w
+}
+
+func TestParallelMatchesSerial(t *testing.T) {
+	serialEvents := &eventRecorder{}
+	parallelEvents := &eventRecorder{}
+	serial := parallelTestWorld(8, serialEvents)
+	parallel := parallelTestWorld(8, parallelEvents)
+	parallel.SetNumWorkers(4)
+
+	for step := range 120 {
+		serial.Step(1.0 / 60)
+		parallel.Step(1.0 / 60)
+		if serial.GetNumIslands() != parallel.GetNumIslands() {
+			t.Fatalf("step %d: %d islands, want %d", step, parallel.GetNumIslands(), serial.GetNumIslands())
+		}
+	}
+
+	b1, b2 := serial.GetRigidBodyList(), parallel.GetRigidBodyList()
+	for b1 != nil {
+		if b1.GetTransform() != b2.GetTransform() || b1.GetLinearVelocity() != b2.GetLinearVelocity() {
+			t.Fatalf("rigid body at %v diverged to %v", b1.GetPosition(), b2.GetPosition())
+		}
+		b1, b2 = b1.next, b2.next
+	}
+
+	if len(serialEvents.events) == 0 {
+		t.Fatal("no contact events")
+	}
+	if !reflect.DeepEqual(serialEvents.events, parallelEvents.events) {
+		t.Fatalf("got %d contact events in a different order than the serial %d", len(parallelEvents.events), len(serialEvents.events))
+	}
+}
+
+func TestDeterministicChecksum(t *testing.T) {
+	w1 := parallelTestWorld(4, nil)
+	w2 := parallelTestWorld(4, nil)
+	w1.SetDeterministic(true)
+	w2.SetDeterministic(true)
+	w2.SetNumWorkers(3)
+
+	initial := w1.Checksum()
+	for step := range 90 {
+		w1.Step(1.0 / 60)
+		w2.Step(1.0 / 60)
+		if w1.Checksum() != w2.Checksum() {
+			t.Fatalf("step %d: checksums differ", step)
+		}
+	}
+	if w1.Checksum() == initial {
+		t.Fatal("checksum didn't change")
+	}
+}
+
+// returns whether the test binary is built for an architecture the compiler doesn't fuse floating-point operations on
+func buildWithoutFusedFloatOps() bool {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	settings := map[string]string{}
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	switch settings["GOARCH"] {
+	case "386":
+		return true
+	case "amd64":
+		return settings["GOAMD64"] == "v1" || settings["GOAMD64"] == "v2"
+	}
+	return false
+}
+
+// the checksum of the deterministic world after 90 steps, the same on every machine without fused floating-point
+// operations
+const deterministicChecksum = 0xf012d004316c44d8
+
+func TestDeterministicChecksumAcrossMachines(t *testing.T) {
+	if !buildWithoutFusedFloatOps() {
+		t.Skip("the compiler may fuse floating-point operations on this architecture")
+	}
+	w := parallelTestWorld(4, nil)
+	w.SetDeterministic(true)
+	for range 90 {
+		w.Step(1.0 / 60)
+	}
+	if c := w.Checksum(); c != deterministicChecksum {
+		t.Fatalf("checksum %#x, expected %#x", c, uint64(deterministicChecksum))
+	}
+}
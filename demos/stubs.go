@@ -6,12 +6,4 @@ type DebugDraw struct{}
 type SphereGeometry struct{}
 type CylinderGeometry struct{}
 type ConeGeometry struct{}
-type CapsuleGeometry struct{}
 type ConvexHullGeometry struct{}
-type RevoluteJoint struct{}
-type CylindricalJoint struct{}
-type PrismaticJoint struct{}
-type UniversalJoint struct{}
-type RagdollJoint struct{}
-type GenericJoint struct{}
-type TranslationalLimitMotor struct{}